@@ -0,0 +1,10 @@
+// Package allocation names the seat allocation strategies, so that
+// configuration can check a strategy name without depending on the service
+// that implements them.
+package allocation
+
+// Default is the strategy used unless another is configured.
+const Default = "balanced"
+
+// Strategies lists the names of the seat allocation strategies, sorted.
+var Strategies = []string{"balanced", "best-fit", "cluster", "front-to-back", "spacing"}
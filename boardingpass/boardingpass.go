@@ -0,0 +1,153 @@
+// Package boardingpass issues and verifies signed boarding passes. A pass is
+// a short text code, usually shown as a QR code, holding the journey, seat
+// and passenger together with an Ed25519 signature. Anyone with the public
+// key can verify a pass offline; Gate adds the cancellation and repeat-scan
+// checks a handheld scanner needs.
+package boardingpass
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	train "ticketing-svc/proto"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// prefix starts every code and identifies its format version.
+const prefix = "BP1."
+
+// ErrTampered is returned for codes that are malformed or whose signature
+// does not match their contents.
+var ErrTampered = errors.New("boarding pass is invalid or has been tampered with")
+
+// Pass is the content of a boarding pass.
+type Pass struct {
+	Reference string `json:"ref"` // booking reference
+	Email     string `json:"email"`
+	FirstName string `json:"fn,omitempty"`
+	LastName  string `json:"ln,omitempty"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to,omitempty"`
+	Seat      string `json:"seat"`
+	Issued    int64  `json:"iat"` // Unix seconds
+}
+
+// FromReceipt returns the pass for the ticket in r, issued now.
+func FromReceipt(r *train.Receipt) Pass {
+	return Pass{
+		Reference: r.GetBookingReference(),
+		Email:     r.GetUser().GetEmail(),
+		FirstName: r.GetUser().GetFirstName(),
+		LastName:  r.GetUser().GetLastName(),
+		From:      r.GetFrom(),
+		To:        r.GetTo(),
+		Seat:      r.GetSeat(),
+		Issued:    time.Now().Unix(),
+	}
+}
+
+// Sign returns the code for p signed with key. The code is "BP1." followed
+// by the base64url JSON of p, a dot and the base64url signature of
+// everything before it.
+func Sign(key ed25519.PrivateKey, p Pass) (string, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	signed := prefix + base64.RawURLEncoding.EncodeToString(payload)
+	sig := ed25519.Sign(key, []byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// Verify checks the signature on code against key and returns its pass.
+func Verify(key ed25519.PublicKey, code string) (Pass, error) {
+	signed, encodedSig, ok := cutLast(code, ".")
+	if !ok || !strings.HasPrefix(signed, prefix) {
+		return Pass{}, ErrTampered
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(encodedSig)
+	if err != nil || !ed25519.Verify(key, []byte(signed), sig) {
+		return Pass{}, ErrTampered
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(signed, prefix))
+	if err != nil {
+		return Pass{}, ErrTampered
+	}
+	var p Pass
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return Pass{}, ErrTampered
+	}
+	return p, nil
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+// QR returns code as a PNG QR code of size pixels square.
+func QR(code string, size int) ([]byte, error) {
+	return qrcode.Encode(code, qrcode.Medium, size)
+}
+
+// LoadPrivateKey reads a PEM encoded PKCS #8 Ed25519 private key, as written
+// by `openssl genpkey -algorithm ed25519`.
+func LoadPrivateKey(path string) (ed25519.PrivateKey, error) {
+	der, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	priv, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 private key", path)
+	}
+	return priv, nil
+}
+
+// LoadPublicKey reads a PEM encoded PKIX Ed25519 public key, as written by
+// `openssl pkey -pubout`.
+func LoadPublicKey(path string) (ed25519.PublicKey, error) {
+	der, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	pub, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: not an Ed25519 public key", path)
+	}
+	return pub, nil
+}
+
+// readPEM returns the bytes of the first PEM block in the file at path.
+func readPEM(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data", path)
+	}
+	return block.Bytes, nil
+}
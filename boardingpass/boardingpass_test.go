@@ -0,0 +1,92 @@
+package boardingpass
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	train "ticketing-svc/proto"
+)
+
+func TestGate_Scan(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+
+	sign := func(key ed25519.PrivateKey, ref, seat string) string {
+		code, err := Sign(key, FromReceipt(&train.Receipt{
+			User:             &train.User{FirstName: "John", Email: "john.doe@example.com"},
+			Seat:             seat,
+			BookingReference: ref,
+		}))
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		return code
+	}
+	valid := sign(priv, "K7QX2MNP", "A-0")
+
+	// Swap the payload for one claiming a different seat, keeping the
+	// original signature.
+	parts := strings.Split(valid, ".")
+	forged := strings.Split(sign(priv, "K7QX2MNP", "B-9"), ".")
+	tampered := strings.Join([]string{parts[0], forged[1], parts[2]}, ".")
+
+	gate := NewGate(pub)
+	gate.Cancel("CANCELD2")
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{name: "success - first scan", code: valid},
+		{name: "fail - second scan", code: valid, wantErr: ErrAlreadyScanned},
+		{name: "fail - payload changed", code: tampered, wantErr: ErrTampered},
+		{name: "fail - signed by another key", code: sign(otherKey, "ABCDEFGH", "A-1"), wantErr: ErrTampered},
+		{name: "fail - garbage", code: "BP1." + base64.RawURLEncoding.EncodeToString([]byte("{}")), wantErr: ErrTampered},
+		{name: "fail - cancelled", code: sign(priv, "CANCELD2", "A-2"), wantErr: ErrCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := gate.Scan(tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Gate.Scan() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (p.Reference != "K7QX2MNP" || p.Seat != "A-0") {
+				t.Errorf("Gate.Scan() = %+v, want the pass for K7QX2MNP in A-0", p)
+			}
+		})
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	dir := t.TempDir()
+	privDER, _ := x509.MarshalPKCS8PrivateKey(priv)
+	pubDER, _ := x509.MarshalPKIXPublicKey(pub)
+	os.WriteFile(filepath.Join(dir, "key.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600)
+	os.WriteFile(filepath.Join(dir, "pub.pem"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600)
+
+	loadedPriv, err := LoadPrivateKey(filepath.Join(dir, "key.pem"))
+	if err != nil {
+		t.Fatalf("LoadPrivateKey() error = %v", err)
+	}
+	loadedPub, err := LoadPublicKey(filepath.Join(dir, "pub.pem"))
+	if err != nil {
+		t.Fatalf("LoadPublicKey() error = %v", err)
+	}
+
+	code, _ := Sign(loadedPriv, Pass{Reference: "K7QX2MNP", Seat: "A-0"})
+	if _, err := Verify(loadedPub, code); err != nil {
+		t.Errorf("Verify() with loaded keys error = %v", err)
+	}
+	if _, err := LoadPublicKey(filepath.Join(dir, "key.pem")); err == nil {
+		t.Error("LoadPublicKey() of a private key error = nil")
+	}
+}
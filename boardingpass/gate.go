@@ -0,0 +1,68 @@
+package boardingpass
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCancelled is returned for passes whose booking was cancelled.
+	ErrCancelled = errors.New("booking has been cancelled")
+	// ErrAlreadyScanned is returned for passes scanned before.
+	ErrAlreadyScanned = errors.New("boarding pass already scanned")
+)
+
+// Gate validates passes offline, as a handheld scanner would: it checks the
+// signature, rejects bookings it has been told are cancelled and accepts
+// each booking only once.
+type Gate struct {
+	key ed25519.PublicKey
+
+	mu        sync.Mutex // protects the following fields
+	cancelled map[string]bool
+	scanned   map[string]time.Time
+}
+
+// NewGate returns a Gate trusting passes signed by the private half of key.
+func NewGate(key ed25519.PublicKey) *Gate {
+	return &Gate{
+		key:       key,
+		cancelled: make(map[string]bool),
+		scanned:   make(map[string]time.Time),
+	}
+}
+
+// Cancel marks the given booking references as cancelled, typically from a
+// list synced while the scanner was online.
+func (g *Gate) Cancel(references ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, ref := range references {
+		g.cancelled[ref] = true
+	}
+}
+
+// Scan validates code and records it as scanned. It returns the pass along
+// with ErrAlreadyScanned for repeat scans so the conductor can see who it
+// belongs to.
+func (g *Gate) Scan(code string) (Pass, error) {
+	p, err := Verify(g.key, code)
+	if err != nil {
+		return Pass{}, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.cancelled[p.Reference] {
+		return p, ErrCancelled
+	}
+	if _, ok := g.scanned[p.Reference]; ok {
+		return p, ErrAlreadyScanned
+	}
+	g.scanned[p.Reference] = time.Now()
+	return p, nil
+}
@@ -0,0 +1,200 @@
+// Package certs builds TLS configurations for the ticket service and its
+// clients, including certificate hot-reload for long running servers.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// ServerOptions describes the files used to build a server TLS configuration.
+type ServerOptions struct {
+	CertFile string // PEM encoded server certificate chain
+	KeyFile  string // PEM encoded private key for CertFile
+	// ClientCAFile enables client certificate verification (mTLS) when set.
+	ClientCAFile string
+	// RequireClientCert rejects clients that do not present a certificate.
+	// When false, certificates are only verified if a client offers one.
+	RequireClientCert bool
+}
+
+// Reloader serves the current certificate and client CA pool to new TLS
+// handshakes and swaps them in place when the files on disk change.
+type Reloader struct {
+	opts ServerOptions
+
+	mu       sync.RWMutex // protects the following fields
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+// NewReloader loads the files described by opts and returns a Reloader
+// serving them.
+func NewReloader(opts ServerOptions) (*Reloader, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, errors.New("certs: both certificate and key files are required")
+	}
+	if opts.RequireClientCert && opts.ClientCAFile == "" {
+		return nil, errors.New("certs: requiring client certificates needs a client CA file")
+	}
+	r := &Reloader{opts: opts}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the certificate, key and client CA files. On error the
+// previously loaded material stays in use.
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("certs: load key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.opts.ClientCAFile != "" {
+		pool, err = LoadCertPool(r.opts.ClientCAFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCA = pool
+	r.modTimes = r.statFiles()
+	return nil
+}
+
+// Watch polls the certificate files every interval and reloads them when
+// their modification time changes. It returns when ctx is done.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				log.Printf("certificate reload failed: %v", err)
+				continue
+			}
+			log.Printf("reloaded TLS certificates from %s", r.opts.CertFile)
+		}
+	}
+}
+
+// TLSConfig returns a server configuration that resolves the certificate and
+// client CA pool on every handshake, so reloads apply to new connections.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			cfg := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+			}
+			if r.clientCA != nil {
+				cfg.ClientCAs = r.clientCA
+				cfg.ClientAuth = tls.VerifyClientCertIfGiven
+				if r.opts.RequireClientCert {
+					cfg.ClientAuth = tls.RequireAndVerifyClientCert
+				}
+			}
+			return cfg, nil
+		},
+	}
+}
+
+// changed reports whether any watched file has a new modification time.
+func (r *Reloader) changed() bool {
+	current := r.statFiles()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for name, mod := range current {
+		if !mod.Equal(r.modTimes[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// statFiles records the modification time of every configured file.
+func (r *Reloader) statFiles() map[string]time.Time {
+	times := make(map[string]time.Time)
+	for _, name := range []string{r.opts.CertFile, r.opts.KeyFile, r.opts.ClientCAFile} {
+		if name == "" {
+			continue
+		}
+		if info, err := os.Stat(name); err == nil {
+			times[name] = info.ModTime()
+		}
+	}
+	return times
+}
+
+// ClientOptions describes the files used to build a client TLS configuration.
+type ClientOptions struct {
+	CAFile     string // PEM bundle used to verify the server; system roots when empty
+	CertFile   string // optional client certificate for mTLS
+	KeyFile    string // private key for CertFile
+	ServerName string // overrides the name checked against the server certificate
+}
+
+// ClientTLSConfig builds a client configuration from opts.
+func ClientTLSConfig(opts ClientOptions) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: opts.ServerName,
+	}
+
+	if opts.CAFile != "" {
+		pool, err := LoadCertPool(opts.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.CertFile != "" || opts.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("certs: load client key pair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// LoadCertPool reads a PEM bundle into a certificate pool.
+func LoadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("certs: read CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("certs: no certificates found in %s", file)
+	}
+	return pool, nil
+}
@@ -2,32 +2,126 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
 	"time"
 
+	"ticketing-svc/certs"
+	"ticketing-svc/config"
+	"ticketing-svc/middleware"
 	train "ticketing-svc/proto"
+	"ticketing-svc/tracing"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
-)
-
-const (
-	address = "localhost:50051"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
-	// Set up a connection to the server.
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	address := flag.String("addr", config.DefaultDialAddr, "server address")
+	apiKey := flag.String("api-key", "", "API key sent with every request")
+	useTLS := flag.Bool("tls", false, "connect using TLS")
+	caFile := flag.String("ca", "", "PEM CA bundle used to verify the server; implies -tls")
+	certFile := flag.String("cert", "", "PEM client certificate for mTLS; implies -tls")
+	keyFile := flag.String("key", "", "PEM private key for -cert")
+	serverName := flag.String("server-name", "", "override the server name checked against its certificate")
+	traceExporter := flag.String("trace-exporter", tracing.ExporterNone, "span exporter: none, otlp or file")
+	traceEndpoint := flag.String("trace-endpoint", "localhost:4317", "OTLP gRPC collector address")
+	traceFile := flag.String("trace-file", "client-traces.json", "output file for the file span exporter")
+	flag.Parse()
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName: "ticketing-client",
+		Exporter:    *traceExporter,
+		Endpoint:    *traceEndpoint,
+		Insecure:    true,
+		File:        *traceFile,
+	})
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	creds := insecure.NewCredentials()
+	if *useTLS || *caFile != "" || *certFile != "" {
+		tlsConfig, err := certs.ClientTLSConfig(certs.ClientOptions{
+			CAFile:     *caFile,
+			CertFile:   *certFile,
+			KeyFile:    *keyFile,
+			ServerName: *serverName,
+		})
+		if err != nil {
+			log.Fatalf("failed to load TLS configuration: %v", err)
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	// Set up a connection to the server, propagating trace context in the
+	// request metadata.
+	conn, err := grpc.Dial(*address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithBlock(),
+	)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
 	defer conn.Close()
 
+	// Subcommands run on their own instead of the integration run below.
+	if flag.NArg() > 0 {
+		ctx := context.Background()
+		if *apiKey != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, *apiKey)
+		}
+		switch flag.Arg(0) {
+		case "manifest":
+			err = exportManifest(ctx, train.NewAgentClient(conn), flag.Args()[1:])
+		case "import":
+			err = importTickets(ctx, train.NewAgentClient(conn), flag.Args()[1:])
+		default:
+			err = fmt.Errorf("unknown command %q", flag.Arg(0))
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Create a client
 	client := train.NewTicketServiceClient(conn)
 	// Create a context with a timeout
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
+	if *apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, *apiKey)
+	}
+
+	// Group every call below under a single trace.
+	ctx, span := otel.Tracer("ticketing-svc/client").Start(ctx, "integration-run")
+	defer span.End()
+
+	// Follow section A while the requests below change it.
+	watch, err := client.WatchSeats(ctx, &train.SectionRequest{Section: "A"})
+	if err != nil {
+		log.Fatalf("Could not watch seats: %v", err)
+	}
+	go func() {
+		for {
+			event, err := watch.Recv()
+			if err != nil {
+				return
+			}
+			log.Printf("Seat Event: %+v", event)
+		}
+	}()
 
 	// Create a purchase request
 	purchaseReq := &train.PurchaseRequest{
@@ -55,6 +149,20 @@ func main() {
 	}
 	log.Printf("Receipt Details: %+v", receipt)
 
+	// Get a printable ticket
+	doc, err := client.GetTicketDocument(ctx, &train.TicketDocumentRequest{Email: userReq.Email})
+	if err != nil {
+		log.Fatalf("Could not get ticket document: %v", err)
+	}
+	log.Printf("Ticket Document: %s, %s, %d bytes", doc.Filename, doc.ContentType, len(doc.Content))
+
+	// Check in for the journey
+	receipt, err = client.CheckIn(ctx, userReq)
+	if err != nil {
+		log.Fatalf("Could not check in: %v", err)
+	}
+	log.Printf("Ticket State: %v", receipt.State)
+
 	// View seats in section A
 	sectionReq := &train.SectionRequest{Section: "A"}
 	seatResp, err := client.ViewSeats(ctx, sectionReq)
@@ -66,7 +174,7 @@ func main() {
 	// Modify the user's seat
 	modifySeatReq := &train.ModifySeatRequest{
 		Email:   "john.doe@example.com",
-		NewSeat: "B1", // Assuming 'B1' is a valid seat representation
+		NewSeat: "B-1",
 	}
 	statusResp, err := client.ModifySeat(ctx, modifySeatReq)
 	if err != nil {
@@ -82,3 +190,120 @@ func main() {
 	}
 	log.Printf("Remove User Response: %+v", statusResp)
 }
+
+// manifestFormats maps the -format values of the manifest command to
+// ExportManifest formats.
+var manifestFormats = map[string]train.ManifestRequest_Format{
+	"csv":   train.ManifestRequest_CSV,
+	"jsonl": train.ManifestRequest_JSON_LINES,
+	"pdf":   train.ManifestRequest_PDF,
+}
+
+// exportManifest implements the manifest command, writing the passenger
+// manifest to a file or standard output as it streams in.
+func exportManifest(ctx context.Context, client train.AgentClient, args []string) error {
+	fs := flag.NewFlagSet("manifest", flag.ExitOnError)
+	journey := fs.String("journey", "", "journey to export")
+	format := fs.String("format", "csv", "document format: csv, jsonl or pdf")
+	output := fs.String("o", "", "file to write; standard output when empty")
+	fs.Parse(args)
+
+	f, ok := manifestFormats[*format]
+	if !ok {
+		return fmt.Errorf("unknown manifest format %q", *format)
+	}
+	stream, err := client.ExportManifest(ctx, &train.ManifestRequest{Journey: *journey, Format: f})
+	if err != nil {
+		return fmt.Errorf("could not export manifest: %w", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not export manifest: %w", err)
+		}
+		if _, err := out.Write(chunk.Content); err != nil {
+			return err
+		}
+	}
+}
+
+// importChunkSize is the most CSV bytes sent in one ImportChunk.
+const importChunkSize = 32 << 10
+
+// importTickets implements the import command, streaming a CSV file of
+// passengers to ImportTickets and printing the outcome of each row.
+func importTickets(ctx context.Context, client train.AgentClient, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "report what would be booked without booking")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: client import [-dry-run] passengers.csv")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("import needs one CSV file")
+	}
+
+	file, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	stream, err := client.ImportTickets(ctx)
+	if err != nil {
+		return fmt.Errorf("could not import tickets: %w", err)
+	}
+	buf := make([]byte, importChunkSize)
+	for first := true; ; first = false {
+		n, err := file.Read(buf)
+		if n > 0 || first {
+			// Always send a first chunk, so the server sees the dry-run flag.
+			if err := stream.Send(&train.ImportChunk{DryRun: *dryRun && first, Content: buf[:n]}); err != nil {
+				break // the server's error is returned by CloseAndRecv
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	report, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("could not import tickets: %w", err)
+	}
+
+	for _, row := range report.Rows {
+		if row.Error != "" {
+			fmt.Printf("line %d\t%s\tfailed: %s\n", row.Line, row.Email, row.Error)
+			continue
+		}
+		seat := row.Receipt.Seat
+		if seat == "" {
+			seat = "overbooked in " + row.Receipt.OverbookedSection
+		}
+		fmt.Printf("line %d\t%s\t%s\t%s\n", row.Line, row.Email, seat, row.Receipt.BookingReference)
+	}
+	verb := "booked"
+	if report.DryRun {
+		verb = "would be booked (dry run)"
+	}
+	fmt.Printf("%d %s, %d failed\n", report.Booked, verb, report.Failed)
+	return nil
+}
@@ -1,31 +1,547 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"ticketing-svc/boardingpass"
+	"ticketing-svc/certs"
 	"ticketing-svc/config"
+	"ticketing-svc/document"
+	"ticketing-svc/events"
+	"ticketing-svc/gateway"
+	"ticketing-svc/healthcheck"
+	"ticketing-svc/metrics"
+	"ticketing-svc/middleware"
+	"ticketing-svc/notify"
 	train "ticketing-svc/proto"
 	"ticketing-svc/service"
+	"ticketing-svc/tracing"
+	"ticketing-svc/webhooks"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/test/bufconn"
 )
 
 func main() {
+	cfg, printConfig, err := config.Load(os.Args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+	if printConfig {
+		if err := cfg.Print(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	setupLogging(cfg.LogLevel)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName: "ticketing-svc",
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		File:        cfg.Tracing.File,
+	})
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+
 	// Create a listener on TCP port
-	lis, err := net.Listen("tcp", config.Port)
+	lis, err := net.Listen("tcp", cfg.ListenAddr)
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	documents, err := document.NewRenderer(cfg.Documents.BrandsDir)
+	if err != nil {
+		log.Fatalf("failed to load ticket brands: %v", err)
+	}
+	allocator, err := service.NewAllocator(cfg.Seats.Allocator)
+	if err != nil {
+		log.Fatalf("failed to set up seat allocation: %v", err)
+	}
+	svcOpts := []service.Option{
+		service.WithSeatLayout(cfg.Seats.Sections, cfg.Seats.SeatsPerSection),
+		service.WithAllocator(allocator),
+		service.WithQuietSections(cfg.Seats.QuietSections...),
+		service.WithBlockedSeats(cfg.Seats.BlockedSeats...),
+		service.WithWheelchairSpaces(cfg.Seats.WheelchairSpaces...),
+		service.WithCompanionSeats(cfg.Seats.CompanionSeats...),
+		service.WithSeatClasses(seatClasses(cfg.Seats.Classes)),
+		service.WithFares(fares(cfg.Seats.Fares)),
+		service.WithOverbooking(cfg.Seats.Overbooking),
+		service.WithStore(newStore(cfg.Storage)),
+		service.WithDocuments(documents),
+	}
+	// Validate has checked the departure, so it is zero only when unset.
+	departure, _ := time.Parse(time.RFC3339, cfg.Departure)
+	if !departure.IsZero() {
+		svcOpts = append(svcOpts, service.WithAccessibleRelease(departure.Add(-time.Duration(cfg.Seats.AccessibleReleaseBefore))))
+	}
+	sinks, err := eventSinks(cfg.Events)
+	if err != nil {
+		log.Fatalf("failed to set up event sinks: %v", err)
+	}
+	webhookManager, err := webhooks.NewManager(webhooks.Options{
+		Path:        cfg.Events.WebhookStore,
+		MaxAttempts: cfg.Events.WebhookMaxAttempts,
+	})
+	if err != nil {
+		log.Fatalf("failed to load webhooks: %v", err)
+	}
+	sinks = append(sinks, webhookManager)
+	notifier, err := newNotifier(cfg.Notify)
+	if err != nil {
+		log.Fatalf("failed to set up notifications: %v", err)
+	}
+	if notifier != nil {
+		sinks = append(sinks, notify.NewSink(notifier))
+	}
+	outbox := events.NewOutbox()
+	svcOpts = append(svcOpts, service.WithOutbox(outbox))
+	if cfg.BoardingPassKey != "" {
+		key, err := boardingpass.LoadPrivateKey(cfg.BoardingPassKey)
+		if err != nil {
+			log.Fatalf("failed to load boarding pass key: %v", err)
+		}
+		svcOpts = append(svcOpts, service.WithBoardingPassKey(key))
+	} else {
+		log.Printf("no boarding pass key configured; passes will not verify after a restart")
+	}
+	svc := service.NewServer(svcOpts...)
+	if err := svc.Restore(context.Background()); err != nil {
+		log.Fatalf("failed to restore bookings: %v", err)
+	}
+
+	// Keep saving bookings and delivering events while the server drains;
+	// whatever is still pending at exit is flushed with the bookings and sent
+	// after a restart.
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	defer stopEvents()
+	go flushBookings(eventsCtx, time.Duration(cfg.Storage.FlushInterval), svc.Flush)
+	go events.NewDispatcher(outbox, cfg.Events.MaxAttempts, sinks...).Run(eventsCtx, time.Duration(cfg.Events.RetryInterval))
+	go webhookManager.Run(eventsCtx)
+	if !departure.IsZero() {
+		go markNoShows(eventsCtx, departure, svc.MarkNoShows)
+		if notifier != nil {
+			go notify.RemindBeforeDeparture(eventsCtx, notifier, departure, time.Duration(cfg.Notify.ReminderBefore), svc.Receipts)
+		}
+	}
+
+	var m *metrics.Metrics
+	var httpServers []*http.Server
+	if cfg.MetricsAddr != "" {
+		m = metrics.New(svc)
+		httpServers = append(httpServers, serveHTTP("metrics", cfg.MetricsAddr, metricsMux(m), nil))
+	}
+
+	tlsConfig, err := loadTLS(cfg.TLS)
+	if err != nil {
+		log.Fatalf("failed to load TLS certificates: %v", err)
+	}
+	opts := serverOptions(cfg, m)
+
 	// Create a gRPC server object
-	s := grpc.NewServer()
+	s := grpc.NewServer(append(opts, transportCredentials(tlsConfig))...)
 	// Attach the train service to the server
-	train.RegisterTicketServiceServer(s, service.NewServer())
+	train.RegisterTicketServiceServer(s, svc)
+	train.RegisterWebhookAdminServer(s, webhooks.NewAdminServer(webhookManager))
+	train.RegisterAgentServer(s, svc)
+	train.RegisterReportsServer(s, svc)
+	grpcServers := []*grpc.Server{s}
+
+	if cfg.GatewayAddr != "" {
+		// The gateway reaches the service through an in-memory gRPC server
+		// sharing the interceptors, so HTTP callers get the same auth,
+		// rate limiting, metrics and tracing as gRPC callers.
+		internal, conn := inProcessServer(svc, opts)
+		grpcServers = append(grpcServers, internal)
+		gw := gateway.New(train.NewTicketServiceClient(conn))
+		httpServers = append(httpServers, serveHTTP("gateway", cfg.GatewayAddr, gw, tlsConfig))
+	}
+
+	// Expose the standard health service, reporting the storage backend
+	// separately, and server reflection for tools such as grpcurl.
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	reflection.Register(s)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	monitor := healthcheck.NewMonitor(healthServer, train.TicketService_ServiceDesc.ServiceName)
+	monitor.Add("storage", svc.CheckStorage)
+	go monitor.Run(ctx, time.Duration(cfg.HealthCheckInterval))
 
 	log.Printf("server listening at %v", lis.Addr())
 
 	// Start serving requests via the listener
-	if err := s.Serve(lis); err != nil {
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- s.Serve(lis) }()
+
+	select {
+	case err := <-serveErr:
 		log.Fatalf("failed to serve: %v", err)
+	case <-ctx.Done():
+		stop()
+	}
+
+	// Report NOT_SERVING before draining so load balancers stop sending
+	// new traffic.
+	monitor.Shutdown()
+	code := shutdown(grpcServers, svc, httpServers, time.Duration(cfg.ShutdownTimeout))
+	stopEvents()
+	if err := shutdownTracing(context.Background()); err != nil {
+		log.Printf("failed to flush traces: %v", err)
+	}
+	os.Exit(code)
+}
+
+// serveHTTP starts an HTTP server for handler on addr in the background,
+// using TLS when tlsConfig is not nil.
+func serveHTTP(name, addr string, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		log.Printf("%s server listening at %v", name, addr)
+		var err error
+		if tlsConfig != nil {
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("failed to serve %s: %v", name, err)
+		}
+	}()
+	return srv
+}
+
+// inProcessServer serves svc on an in-memory listener with opts and returns
+// the server together with a client connection to it.
+func inProcessServer(svc train.TicketServiceServer, opts []grpc.ServerOption) (*grpc.Server, *grpc.ClientConn) {
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(opts...)
+	train.RegisterTicketServiceServer(s, svc)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Fatalf("failed to serve in-process: %v", err)
+		}
+	}()
+
+	conn, err := grpc.Dial("in-process",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		log.Fatalf("failed to connect in-process: %v", err)
+	}
+	return s, conn
+}
+
+// metricsMux serves the Prometheus registry on /metrics.
+func metricsMux(m *metrics.Metrics) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+	return mux
+}
+
+// seatClasses converts the configured class name of each section.
+func seatClasses(names map[string]string) map[string]train.SeatClass {
+	classes := make(map[string]train.SeatClass, len(names))
+	for section, name := range names {
+		classes[section] = train.SeatClass(train.SeatClass_value[strings.ToUpper(name)])
+	}
+	return classes
+}
+
+// fares converts the configured fares to be keyed by class.
+func fares(byName map[string]float64) map[train.SeatClass]float64 {
+	fares := make(map[train.SeatClass]float64, len(byName))
+	for name, fare := range byName {
+		fares[train.SeatClass(train.SeatClass_value[strings.ToUpper(name)])] = fare
+	}
+	return fares
+}
+
+// flushBookings calls flush every interval until ctx is done, so changes are
+// saved and their events released while the server runs.
+func flushBookings(ctx context.Context, interval time.Duration, flush func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := flush(ctx); err != nil {
+				log.Printf("failed to flush bookings: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// markNoShows calls mark when the train departs, unless ctx is cancelled
+// first.
+func markNoShows(ctx context.Context, departure time.Time, mark func() int) {
+	timer := time.NewTimer(time.Until(departure))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		log.Printf("train departed, %d tickets marked as no-shows", mark())
+	case <-ctx.Done():
+	}
+}
+
+// bookingService is the part of the ticket service involved in shutdown.
+type bookingService interface {
+	Drain(ctx context.Context) error
+	Flush(ctx context.Context) error
+}
+
+// shutdown stops the gRPC and HTTP servers, draining in-flight requests until
+// timeout, then flushes booking state. It returns the process exit status.
+func shutdown(grpcServers []*grpc.Server, svc bookingService, httpServers []*http.Server, timeout time.Duration) int {
+	log.Printf("shutting down, waiting up to %v for in-flight requests", timeout)
+	code := 0
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// Stop the HTTP servers first: gateway requests still in flight need
+	// the in-process gRPC server to complete.
+	for _, srv := range httpServers {
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("failed to stop HTTP server %s: %v", srv.Addr, err)
+			code = 1
+		}
+	}
+
+	// Refuse new purchases and end WatchSeats streams, which would
+	// otherwise keep GracefulStop waiting until the deadline.
+	if err := svc.Drain(ctx); err != nil {
+		log.Printf("in-flight purchases did not finish: %v", err)
+		code = 1
+	}
+
+	// GracefulStop closes the listeners immediately and returns once every
+	// in-flight RPC has completed.
+	stopped := make(chan struct{})
+	go func() {
+		for _, s := range grpcServers {
+			s.GracefulStop()
+		}
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Printf("shutdown deadline exceeded, closing remaining connections")
+		for _, s := range grpcServers {
+			s.Stop()
+		}
+		code = 1
+	}
+
+	if err := svc.Flush(ctx); err != nil {
+		log.Printf("failed to flush bookings: %v", err)
+		code = 1
+	}
+
+	if code == 0 {
+		log.Printf("shutdown complete")
+	} else {
+		log.Printf("shutdown finished with errors")
+	}
+	return code
+}
+
+// newStore returns the booking store selected by cfg.
+func newStore(cfg config.StorageConfig) service.Store {
+	if cfg.Backend == "file" {
+		return service.NewFileStore(cfg.Path)
+	}
+	return service.NewMemoryStore()
+}
+
+// eventSinks returns the booking event sinks selected by cfg.
+func eventSinks(cfg config.EventsConfig) ([]events.Sink, error) {
+	var sinks []events.Sink
+	if cfg.File != "" {
+		sink, err := events.NewFileSink(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	for _, url := range cfg.Webhooks {
+		client := &http.Client{Timeout: 10 * time.Second}
+		sinks = append(sinks, events.NewWebhookSink(url, cfg.WebhookSecret, client))
+	}
+	if cfg.Broker {
+		broker := events.NewBroker()
+		received, _ := broker.Subscribe(64)
+		go logEvents(received)
+		sinks = append(sinks, broker)
+	}
+	return sinks, nil
+}
+
+// logEvents logs every event received from a broker subscription at debug
+// level, as an example consumer.
+func logEvents(received <-chan events.Event) {
+	for e := range received {
+		slog.Debug("booking event", "id", e.ID, "type", e.Type, "email", e.Email, "seat", e.Seat)
+	}
+}
+
+// newNotifier returns the passenger notifier selected by cfg, or nil when
+// notifications are disabled.
+func newNotifier(cfg config.NotifyConfig) (notify.Notifier, error) {
+	switch cfg.Transport {
+	case "console":
+		return notify.NewWriterNotifier(os.Stdout), nil
+	case "file":
+		return notify.NewFileNotifier(cfg.File)
+	case "smtp":
+		return &notify.SMTPNotifier{
+			Addr:     cfg.SMTP.Addr,
+			From:     cfg.SMTP.From,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+		}, nil
+	case "sms":
+		// Passengers have no phone numbers yet, so texts go to the stub,
+		// addressed by email, until an SMS gateway is wired in.
+		return &notify.SMSNotifier{Sender: notify.NewStubSMSSender(os.Stdout)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// setupLogging routes the standard logger through slog at the given level.
+func setupLogging(level string) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		log.Fatalf("invalid log level: %v", err)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: l})))
+}
+
+// loadTLS returns the server TLS configuration, or nil when TLS is disabled.
+func loadTLS(cfg config.TLSConfig) (*tls.Config, error) {
+	if cfg.CertFile == "" {
+		return nil, nil
+	}
+
+	reloader, err := certs.NewReloader(certs.ServerOptions{
+		CertFile:          cfg.CertFile,
+		KeyFile:           cfg.KeyFile,
+		ClientCAFile:      cfg.ClientCAFile,
+		RequireClientCert: cfg.RequireClientCert,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Pick up renewed certificates without a restart, either on a
+	// timer or immediately on SIGHUP.
+	go reloader.Watch(context.Background(), time.Duration(cfg.ReloadInterval))
+	go reloadOnHangup(reloader)
+
+	return reloader.TLSConfig(), nil
+}
+
+// transportCredentials returns TLS credentials for tlsConfig, or plaintext
+// when it is nil.
+func transportCredentials(tlsConfig *tls.Config) grpc.ServerOption {
+	if tlsConfig == nil {
+		return grpc.Creds(insecure.NewCredentials())
+	}
+	return grpc.Creds(credentials.NewTLS(tlsConfig))
+}
+
+// serverOptions builds the interceptors shared by every gRPC server from cfg.
+// Metrics, when m is not nil, are recorded first so rejected requests are
+// counted too.
+func serverOptions(cfg *config.Config, m *metrics.Metrics) []grpc.ServerOption {
+	// Continue traces started by callers and record a span per RPC.
+	opts := []grpc.ServerOption{grpc.StatsHandler(otelgrpc.NewServerHandler())}
+
+	var unary []grpc.UnaryServerInterceptor
+	var stream []grpc.StreamServerInterceptor
+	if m != nil {
+		unary = append(unary, m.UnaryServerInterceptor())
+		stream = append(stream, m.StreamServerInterceptor())
+	}
+	if cfg.RateLimit.RequestsPerSecond > 0 {
+		limiter := rate.NewLimiter(rate.Limit(cfg.RateLimit.RequestsPerSecond), cfg.RateLimit.Burst)
+		unary = append(unary, middleware.RateLimit(limiter))
+		stream = append(stream, middleware.StreamRateLimit(limiter))
+	}
+	if len(cfg.Auth.APIKeys) > 0 || len(cfg.Auth.AdminAPIKeys) > 0 {
+		// Admin keys work everywhere.
+		keys := append(slices.Clone(cfg.Auth.APIKeys), cfg.Auth.AdminAPIKeys...)
+		unary = append(unary, middleware.APIKey(keys))
+		stream = append(stream, middleware.StreamAPIKey(keys))
+	}
+	// Only admin keys may call the admin services, so without any they
+	// refuse every call.
+	if len(cfg.Auth.AdminAPIKeys) == 0 {
+		log.Printf("no admin API keys configured; the WebhookAdmin, Agent and Reports services refuse every call")
+	}
+	adminServices := []string{
+		train.WebhookAdmin_ServiceDesc.ServiceName,
+		train.Agent_ServiceDesc.ServiceName,
+		train.Reports_ServiceDesc.ServiceName,
+	}
+	for _, service := range adminServices {
+		unary = append(unary, middleware.ServiceAPIKey(service, cfg.Auth.AdminAPIKeys))
+		stream = append(stream, middleware.StreamServiceAPIKey(service, cfg.Auth.AdminAPIKeys))
+	}
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	return opts
+}
+
+// reloadOnHangup reloads the TLS certificates every time SIGHUP is received.
+func reloadOnHangup(r *certs.Reloader) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		if err := r.Reload(); err != nil {
+			log.Printf("certificate reload failed: %v", err)
+			continue
+		}
+		log.Printf("reloaded TLS certificates")
 	}
 }
@@ -0,0 +1,26 @@
+// Command openapi-gen writes the OpenAPI document for the HTTP/JSON gateway.
+// It is run by go generate in the gateway package.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"ticketing-svc/openapi"
+)
+
+func main() {
+	root := flag.String("root", ".", "directory proto imports are resolved against")
+	protoFile := flag.String("proto", "proto/ticketing.proto", "proto file defining TicketService, relative to -root")
+	out := flag.String("out", "openapi.json", "output file")
+	flag.Parse()
+
+	spec, err := openapi.Generate(*root, *protoFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, spec, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
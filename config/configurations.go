@@ -1,5 +1,432 @@
+// Package config loads the ticket service configuration from defaults, an
+// optional YAML or TOML file, TICKETING_* environment variables and
+// command-line flags, in increasing order of precedence.
 package config
 
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ticketing-svc/allocation"
+)
+
 const (
-	Port = ":50051"
+	// DefaultListenAddr is the address the gRPC server listens on.
+	DefaultListenAddr = ":50051"
+	// DefaultDialAddr is the address clients connect to by default.
+	DefaultDialAddr = "localhost:50051"
 )
+
+// Config is the effective configuration of the ticket service.
+type Config struct {
+	ListenAddr  string          `yaml:"listen_addr" toml:"listen_addr"`
+	MetricsAddr string          `yaml:"metrics_addr" toml:"metrics_addr"` // HTTP /metrics; empty disables it
+	GatewayAddr string          `yaml:"gateway_addr" toml:"gateway_addr"` // HTTP/JSON gateway; empty disables it
+	LogLevel    string          `yaml:"log_level" toml:"log_level"`
+	Storage     StorageConfig   `yaml:"storage" toml:"storage"`
+	Seats       SeatConfig      `yaml:"seats" toml:"seats"`
+	TLS         TLSConfig       `yaml:"tls" toml:"tls"`
+	Auth        AuthConfig      `yaml:"auth" toml:"auth"`
+	RateLimit   RateLimitConfig `yaml:"rate_limit" toml:"rate_limit"`
+	Tracing     TracingConfig   `yaml:"tracing" toml:"tracing"`
+	Events      EventsConfig    `yaml:"events" toml:"events"`
+	Notify      NotifyConfig    `yaml:"notifications" toml:"notifications"`
+	Documents   DocumentsConfig `yaml:"documents" toml:"documents"`
+	// Departure is the RFC 3339 departure time of the train. It releases
+	// accessible seats, marks tickets that have not boarded as no-shows and
+	// schedules departure reminders; empty disables all three.
+	Departure string `yaml:"departure" toml:"departure"`
+	// BoardingPassKey is the PEM Ed25519 private key boarding passes are
+	// signed with. When empty a key is generated on every start, so passes
+	// stop verifying after a restart.
+	BoardingPassKey string `yaml:"boarding_pass_key" toml:"boarding_pass_key"`
+	// ShutdownTimeout bounds how long in-flight RPCs may run after a
+	// shutdown signal before the server is stopped forcefully.
+	ShutdownTimeout Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout"`
+	// HealthCheckInterval is how often dependencies are probed for the
+	// grpc.health.v1 service.
+	HealthCheckInterval Duration `yaml:"health_check_interval" toml:"health_check_interval"`
+}
+
+// StorageConfig selects where bookings are kept.
+type StorageConfig struct {
+	Backend string `yaml:"backend" toml:"backend"` // "memory" or "file"
+	Path    string `yaml:"path" toml:"path"`       // snapshot file for the file backend
+	// FlushInterval is how often changed bookings are saved. Booking events
+	// are only sent once the change they describe has been saved.
+	FlushInterval Duration `yaml:"flush_interval" toml:"flush_interval"`
+}
+
+// SeatConfig describes the seat layout of the train.
+type SeatConfig struct {
+	Sections        []string `yaml:"sections" toml:"sections"`
+	SeatsPerSection int      `yaml:"seats_per_section" toml:"seats_per_section"`
+	QuietSections   []string `yaml:"quiet_sections" toml:"quiet_sections"` // sections that are quiet coaches
+	BlockedSeats    []string `yaml:"blocked_seats" toml:"blocked_seats"`   // seats out of sale, e.g. "A-3"
+	// Allocator is the seat allocation strategy, one of
+	// allocation.Strategies.
+	Allocator string `yaml:"allocator" toml:"allocator"`
+	// WheelchairSpaces and CompanionSeats are kept for passengers needing
+	// them until AccessibleReleaseBefore the departure.
+	WheelchairSpaces        []string `yaml:"wheelchair_spaces" toml:"wheelchair_spaces"`
+	CompanionSeats          []string `yaml:"companion_seats" toml:"companion_seats"`
+	AccessibleReleaseBefore Duration `yaml:"accessible_release_before" toml:"accessible_release_before"`
+	// Classes maps section names to standard, business or first; sections
+	// not listed are standard. Fares maps class names to the price charged.
+	Classes map[string]string  `yaml:"classes" toml:"classes"`
+	Fares   map[string]float64 `yaml:"fares" toml:"fares"`
+	// Overbooking maps section names to the percentage of their seats that
+	// may be sold again beyond capacity.
+	Overbooking map[string]int `yaml:"overbooking" toml:"overbooking"`
+}
+
+// SeatClasses lists the seat class names, from lowest to highest.
+var SeatClasses = []string{"standard", "business", "first"}
+
+// TLSConfig holds the server certificate settings. TLS is disabled when
+// CertFile is empty.
+type TLSConfig struct {
+	CertFile          string   `yaml:"cert_file" toml:"cert_file"`
+	KeyFile           string   `yaml:"key_file" toml:"key_file"`
+	ClientCAFile      string   `yaml:"client_ca_file" toml:"client_ca_file"`
+	RequireClientCert bool     `yaml:"require_client_cert" toml:"require_client_cert"`
+	ReloadInterval    Duration `yaml:"reload_interval" toml:"reload_interval"`
+}
+
+// AuthConfig lists the API keys accepted by the server. Authentication of
+// the TicketService is disabled when no keys are configured.
+type AuthConfig struct {
+	APIKeys []string `yaml:"api_keys" toml:"api_keys"`
+	// AdminAPIKeys are accepted everywhere and are the only keys allowed to
+	// call the WebhookAdmin, Agent and Reports services. Without any, those
+	// services refuse every call.
+	AdminAPIKeys []string `yaml:"admin_api_keys" toml:"admin_api_keys"`
+}
+
+// RateLimitConfig bounds the request rate accepted by the server. A zero
+// RequestsPerSecond disables rate limiting.
+type RateLimitConfig struct {
+	RequestsPerSecond float64 `yaml:"requests_per_second" toml:"requests_per_second"`
+	Burst             int     `yaml:"burst" toml:"burst"`
+}
+
+// TracingConfig selects where OpenTelemetry spans are exported.
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" toml:"exporter"` // "none", "otlp" or "file"
+	Endpoint string `yaml:"endpoint" toml:"endpoint"` // OTLP gRPC collector address
+	Insecure bool   `yaml:"insecure" toml:"insecure"` // connect to the collector without TLS
+	File     string `yaml:"file" toml:"file"`         // output path for the file exporter
+}
+
+// EventsConfig selects the sinks that receive booking lifecycle events, in
+// addition to the webhooks registered through the WebhookAdmin service.
+type EventsConfig struct {
+	File     string   `yaml:"file" toml:"file"`         // JSON lines log of every event
+	Webhooks []string `yaml:"webhooks" toml:"webhooks"` // URLs every event is POSTed to
+	// WebhookSecret signs the events POSTed to Webhooks, as registered
+	// webhooks are signed with their own secret. Required with Webhooks.
+	WebhookSecret string `yaml:"webhook_secret" toml:"webhook_secret"`
+	// Broker publishes events to the in-process broker standing in for
+	// NATS or Kafka; the server subscribes and logs each one at debug level.
+	Broker        bool     `yaml:"broker" toml:"broker"`
+	RetryInterval Duration `yaml:"retry_interval" toml:"retry_interval"` // delay before retrying failed deliveries
+	// MaxAttempts is how many times an event is offered to a sink before
+	// the sink is given up on for that event.
+	MaxAttempts int `yaml:"max_attempts" toml:"max_attempts"`
+	// WebhookStore is the file registered webhooks and their queued
+	// deliveries are kept in; empty keeps them in memory only.
+	WebhookStore string `yaml:"webhook_store" toml:"webhook_store"`
+	// WebhookMaxAttempts is how many times a webhook delivery is tried
+	// before it is dead-lettered.
+	WebhookMaxAttempts int `yaml:"webhook_max_attempts" toml:"webhook_max_attempts"`
+}
+
+// NotifyConfig selects how passengers are notified about their tickets.
+type NotifyConfig struct {
+	Transport string     `yaml:"transport" toml:"transport"` // "none", "console", "file", "smtp" or "sms"
+	File      string     `yaml:"file" toml:"file"`           // output path for the file transport
+	SMTP      SMTPConfig `yaml:"smtp" toml:"smtp"`
+	// ReminderBefore is how long before the departure reminders are sent.
+	ReminderBefore Duration `yaml:"reminder_before" toml:"reminder_before"`
+}
+
+// SMTPConfig describes the relay used by the smtp transport.
+type SMTPConfig struct {
+	Addr     string `yaml:"addr" toml:"addr"` // host:port
+	From     string `yaml:"from" toml:"from"` // sender address
+	Username string `yaml:"username" toml:"username"`
+	Password string `yaml:"password" toml:"password"`
+}
+
+// DocumentsConfig configures printable tickets.
+type DocumentsConfig struct {
+	// BrandsDir holds one subdirectory per brand, each with an optional
+	// brand.json and ticket.html. Empty uses the built-in brand only.
+	BrandsDir string `yaml:"brands_dir" toml:"brands_dir"`
+}
+
+// Duration is a time.Duration written as a string such as "30s" in
+// configuration files.
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Default returns the configuration used when nothing else is specified.
+func Default() *Config {
+	return &Config{
+		ListenAddr:  DefaultListenAddr,
+		MetricsAddr: ":9090",
+		GatewayAddr: ":8080",
+		LogLevel:    "info",
+		Storage:     StorageConfig{Backend: "memory", FlushInterval: Duration(time.Second)},
+		Seats: SeatConfig{
+			Sections:        []string{"A", "B"},
+			SeatsPerSection: 10,
+			Allocator:       allocation.Default,
+			// Matches the usual 24 hours' notice for booked assistance.
+			AccessibleReleaseBefore: Duration(24 * time.Hour),
+		},
+		TLS: TLSConfig{ReloadInterval: Duration(time.Minute)},
+		Tracing: TracingConfig{
+			Exporter: "none",
+			Endpoint: "localhost:4317",
+			Insecure: true,
+		},
+		Events: EventsConfig{
+			RetryInterval:      Duration(5 * time.Second),
+			MaxAttempts:        10,
+			WebhookMaxAttempts: 8,
+		},
+		Notify: NotifyConfig{
+			Transport:      "none",
+			ReminderBefore: Duration(2 * time.Hour),
+		},
+		ShutdownTimeout:     Duration(30 * time.Second),
+		HealthCheckInterval: Duration(10 * time.Second),
+	}
+}
+
+// Validate reports every problem with c in a single error.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.ListenAddr); err != nil {
+		errs = append(errs, fmt.Errorf("listen_addr: %w", err))
+	}
+
+	if c.MetricsAddr != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+			errs = append(errs, fmt.Errorf("metrics_addr: %w", err))
+		}
+	}
+	if c.GatewayAddr != "" {
+		if _, _, err := net.SplitHostPort(c.GatewayAddr); err != nil {
+			errs = append(errs, fmt.Errorf("gateway_addr: %w", err))
+		}
+	}
+
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log_level: unknown level %q", c.LogLevel))
+	}
+
+	switch c.Storage.Backend {
+	case "memory":
+	case "file":
+		if c.Storage.Path == "" {
+			errs = append(errs, errors.New("storage.path: required for the file backend"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("storage.backend: unknown backend %q", c.Storage.Backend))
+	}
+	if c.Storage.FlushInterval <= 0 {
+		errs = append(errs, errors.New("storage.flush_interval: must be positive"))
+	}
+
+	if len(c.Seats.Sections) == 0 {
+		errs = append(errs, errors.New("seats.sections: at least one section is required"))
+	}
+	seen := make(map[string]bool)
+	for _, name := range c.Seats.Sections {
+		if strings.TrimSpace(name) == "" || seen[name] {
+			errs = append(errs, fmt.Errorf("seats.sections: empty or duplicate section %q", name))
+		}
+		seen[name] = true
+	}
+	for _, name := range c.Seats.QuietSections {
+		if !seen[name] {
+			errs = append(errs, fmt.Errorf("seats.quiet_sections: unknown section %q", name))
+		}
+	}
+	for _, list := range []struct {
+		key    string
+		labels []string
+	}{
+		{"blocked_seats", c.Seats.BlockedSeats},
+		{"wheelchair_spaces", c.Seats.WheelchairSpaces},
+		{"companion_seats", c.Seats.CompanionSeats},
+	} {
+		for _, label := range list.labels {
+			i := strings.LastIndex(label, "-")
+			if n, err := strconv.Atoi(label[i+1:]); i <= 0 || err != nil || !seen[label[:i]] || n < 0 || n >= c.Seats.SeatsPerSection {
+				errs = append(errs, fmt.Errorf("seats.%s: no seat %q on the train", list.key, label))
+			}
+		}
+	}
+	for _, section := range sortedKeys(c.Seats.Classes) {
+		class := c.Seats.Classes[section]
+		if !seen[section] {
+			errs = append(errs, fmt.Errorf("seats.classes: unknown section %q", section))
+		}
+		if !slices.Contains(SeatClasses, class) {
+			errs = append(errs, fmt.Errorf("seats.classes: unknown class %q for section %s", class, section))
+		}
+	}
+	for _, class := range sortedKeys(c.Seats.Fares) {
+		fare := c.Seats.Fares[class]
+		if !slices.Contains(SeatClasses, class) || fare < 0 {
+			errs = append(errs, fmt.Errorf("seats.fares: invalid fare %v for class %q", fare, class))
+		}
+	}
+	for _, section := range sortedKeys(c.Seats.Overbooking) {
+		if percent := c.Seats.Overbooking[section]; !seen[section] || percent < 0 || percent > 100 {
+			errs = append(errs, fmt.Errorf("seats.overbooking: invalid percentage %d for section %q", percent, section))
+		}
+	}
+	if !slices.Contains(allocation.Strategies, c.Seats.Allocator) {
+		errs = append(errs, fmt.Errorf("seats.allocator: unknown strategy %q", c.Seats.Allocator))
+	}
+	if c.Seats.SeatsPerSection <= 0 {
+		errs = append(errs, errors.New("seats.seats_per_section: must be positive"))
+	}
+
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("tls: cert_file and key_file must be set together"))
+	}
+	if c.TLS.ClientCAFile != "" && c.TLS.CertFile == "" {
+		errs = append(errs, errors.New("tls.client_ca_file: requires cert_file"))
+	}
+	if c.TLS.RequireClientCert && c.TLS.ClientCAFile == "" {
+		errs = append(errs, errors.New("tls.require_client_cert: requires client_ca_file"))
+	}
+	if c.TLS.ReloadInterval <= 0 {
+		errs = append(errs, errors.New("tls.reload_interval: must be positive"))
+	}
+
+	for _, key := range c.Auth.APIKeys {
+		if strings.TrimSpace(key) == "" {
+			errs = append(errs, errors.New("auth.api_keys: keys must not be empty"))
+			break
+		}
+	}
+	for _, key := range c.Auth.AdminAPIKeys {
+		if strings.TrimSpace(key) == "" {
+			errs = append(errs, errors.New("auth.admin_api_keys: keys must not be empty"))
+			break
+		}
+	}
+
+	if c.RateLimit.RequestsPerSecond < 0 {
+		errs = append(errs, errors.New("rate_limit.requests_per_second: must not be negative"))
+	}
+	if c.RateLimit.RequestsPerSecond > 0 && c.RateLimit.Burst < 1 {
+		errs = append(errs, errors.New("rate_limit.burst: must be at least 1 when rate limiting is enabled"))
+	}
+
+	switch c.Tracing.Exporter {
+	case "none":
+	case "otlp":
+		if c.Tracing.Endpoint == "" {
+			errs = append(errs, errors.New("tracing.endpoint: required for the otlp exporter"))
+		}
+	case "file":
+		if c.Tracing.File == "" {
+			errs = append(errs, errors.New("tracing.file: required for the file exporter"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: unknown exporter %q", c.Tracing.Exporter))
+	}
+
+	for _, hook := range c.Events.Webhooks {
+		if u, err := url.Parse(hook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("events.webhooks: %q is not an http(s) URL", hook))
+		}
+	}
+	if len(c.Events.Webhooks) > 0 && c.Events.WebhookSecret == "" {
+		errs = append(errs, errors.New("events.webhook_secret: required with events.webhooks"))
+	}
+	if c.Events.RetryInterval <= 0 {
+		errs = append(errs, errors.New("events.retry_interval: must be positive"))
+	}
+	if c.Events.MaxAttempts < 1 {
+		errs = append(errs, errors.New("events.max_attempts: must be at least 1"))
+	}
+	if c.Events.WebhookMaxAttempts < 1 {
+		errs = append(errs, errors.New("events.webhook_max_attempts: must be at least 1"))
+	}
+
+	switch c.Notify.Transport {
+	case "none", "console", "sms":
+	case "file":
+		if c.Notify.File == "" {
+			errs = append(errs, errors.New("notifications.file: required for the file transport"))
+		}
+	case "smtp":
+		if _, _, err := net.SplitHostPort(c.Notify.SMTP.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("notifications.smtp.addr: %w", err))
+		}
+		if c.Notify.SMTP.From == "" {
+			errs = append(errs, errors.New("notifications.smtp.from: required for the smtp transport"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("notifications.transport: unknown transport %q", c.Notify.Transport))
+	}
+	if c.Notify.ReminderBefore < 0 {
+		errs = append(errs, errors.New("notifications.reminder_before: must not be negative"))
+	}
+
+	if c.Departure != "" {
+		if _, err := time.Parse(time.RFC3339, c.Departure); err != nil {
+			errs = append(errs, fmt.Errorf("departure: %w", err))
+		}
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("shutdown_timeout: must be positive"))
+	}
+	if c.HealthCheckInterval <= 0 {
+		errs = append(errs, errors.New("health_check_interval: must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// sortedKeys returns the keys of m in order, so errors are reported in a
+// stable order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
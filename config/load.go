@@ -0,0 +1,399 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"ticketing-svc/allocation"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix is prepended to the upper-cased setting name to form its
+// environment variable, e.g. listen-addr becomes TICKETING_LISTEN_ADDR.
+const envPrefix = "TICKETING_"
+
+// setting is a configuration value that can be overridden by environment
+// variable and command-line flag.
+type setting struct {
+	name   string
+	usage  string
+	isBool bool
+	set    func(c *Config, v string) error
+}
+
+var settings = []setting{
+	{name: "listen-addr", usage: "address the gRPC server listens on", set: func(c *Config, v string) error {
+		c.ListenAddr = v
+		return nil
+	}},
+	{name: "metrics-addr", usage: "address serving Prometheus /metrics over HTTP; empty disables it", set: func(c *Config, v string) error {
+		c.MetricsAddr = v
+		return nil
+	}},
+	{name: "gateway-addr", usage: "address serving the HTTP/JSON gateway; empty disables it", set: func(c *Config, v string) error {
+		c.GatewayAddr = v
+		return nil
+	}},
+	{name: "log-level", usage: "minimum log level: debug, info, warn or error", set: func(c *Config, v string) error {
+		c.LogLevel = strings.ToLower(v)
+		return nil
+	}},
+	{name: "storage-backend", usage: "booking storage backend: memory or file", set: func(c *Config, v string) error {
+		c.Storage.Backend = v
+		return nil
+	}},
+	{name: "storage-path", usage: "snapshot file used by the file storage backend", set: func(c *Config, v string) error {
+		c.Storage.Path = v
+		return nil
+	}},
+	{name: "storage-flush-interval", usage: "how often changed bookings are saved and their events released", set: func(c *Config, v string) error {
+		return c.Storage.FlushInterval.UnmarshalText([]byte(v))
+	}},
+	{name: "seat-sections", usage: "comma separated train section names", set: func(c *Config, v string) error {
+		c.Seats.Sections = splitList(v)
+		return nil
+	}},
+	{name: "seats-per-section", usage: "number of seats in each section", set: func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		c.Seats.SeatsPerSection = n
+		return err
+	}},
+	{name: "seat-quiet-sections", usage: "comma separated sections that are quiet coaches", set: func(c *Config, v string) error {
+		c.Seats.QuietSections = splitList(v)
+		return nil
+	}},
+	{name: "seat-blocked", usage: "comma separated seats taken out of sale, e.g. A-3", set: func(c *Config, v string) error {
+		c.Seats.BlockedSeats = splitList(v)
+		return nil
+	}},
+	{name: "seat-allocator", usage: "seat allocation strategy: " + strings.Join(allocation.Strategies, ", "), set: func(c *Config, v string) error {
+		c.Seats.Allocator = v
+		return nil
+	}},
+	{name: "seat-wheelchair-spaces", usage: "comma separated seats that are wheelchair spaces", set: func(c *Config, v string) error {
+		c.Seats.WheelchairSpaces = splitList(v)
+		return nil
+	}},
+	{name: "seat-companion-seats", usage: "comma separated seats kept for companions of wheelchair users", set: func(c *Config, v string) error {
+		c.Seats.CompanionSeats = splitList(v)
+		return nil
+	}},
+	{name: "seat-accessible-release-before", usage: "how long before departure wheelchair and companion seats open to everyone", set: func(c *Config, v string) error {
+		return c.Seats.AccessibleReleaseBefore.UnmarshalText([]byte(v))
+	}},
+	{name: "seat-classes", usage: "comma separated section=class pairs, e.g. A=first; other sections are standard", set: func(c *Config, v string) error {
+		pairs, err := splitPairs(v)
+		c.Seats.Classes = pairs
+		return err
+	}},
+	{name: "seat-fares", usage: "comma separated class=fare pairs, e.g. first=80,standard=20", set: func(c *Config, v string) error {
+		pairs, err := splitPairs(v)
+		if err != nil {
+			return err
+		}
+		c.Seats.Fares = make(map[string]float64, len(pairs))
+		for class, fare := range pairs {
+			if c.Seats.Fares[class], err = strconv.ParseFloat(fare, 64); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{name: "seat-overbooking", usage: "comma separated section=percent pairs of seats that may be sold beyond capacity", set: func(c *Config, v string) error {
+		pairs, err := splitPairs(v)
+		if err != nil {
+			return err
+		}
+		c.Seats.Overbooking = make(map[string]int, len(pairs))
+		for section, percent := range pairs {
+			if c.Seats.Overbooking[section], err = strconv.Atoi(percent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{name: "tls-cert", usage: "PEM certificate file; enables TLS when set", set: func(c *Config, v string) error {
+		c.TLS.CertFile = v
+		return nil
+	}},
+	{name: "tls-key", usage: "PEM private key file for -tls-cert", set: func(c *Config, v string) error {
+		c.TLS.KeyFile = v
+		return nil
+	}},
+	{name: "tls-client-ca", usage: "PEM CA bundle used to verify client certificates (mTLS)", set: func(c *Config, v string) error {
+		c.TLS.ClientCAFile = v
+		return nil
+	}},
+	{name: "tls-require-client-cert", usage: "reject clients without a certificate signed by -tls-client-ca", isBool: true, set: func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		c.TLS.RequireClientCert = b
+		return err
+	}},
+	{name: "tls-reload-interval", usage: "how often to check certificate files for changes", set: func(c *Config, v string) error {
+		return c.TLS.ReloadInterval.UnmarshalText([]byte(v))
+	}},
+	{name: "auth-api-keys", usage: "comma separated API keys accepted in x-api-key metadata", set: func(c *Config, v string) error {
+		c.Auth.APIKeys = splitList(v)
+		return nil
+	}},
+	{name: "auth-admin-api-keys", usage: "comma separated API keys allowed to call the WebhookAdmin, Agent and Reports services", set: func(c *Config, v string) error {
+		c.Auth.AdminAPIKeys = splitList(v)
+		return nil
+	}},
+	{name: "rate-limit-rps", usage: "requests per second accepted by the server; 0 disables limiting", set: func(c *Config, v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		c.RateLimit.RequestsPerSecond = f
+		return err
+	}},
+	{name: "rate-limit-burst", usage: "requests allowed in a burst above the rate limit", set: func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		c.RateLimit.Burst = n
+		return err
+	}},
+	{name: "trace-exporter", usage: "span exporter: none, otlp or file", set: func(c *Config, v string) error {
+		c.Tracing.Exporter = v
+		return nil
+	}},
+	{name: "trace-endpoint", usage: "OTLP gRPC collector address", set: func(c *Config, v string) error {
+		c.Tracing.Endpoint = v
+		return nil
+	}},
+	{name: "trace-insecure", usage: "connect to the OTLP collector without TLS", isBool: true, set: func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		c.Tracing.Insecure = b
+		return err
+	}},
+	{name: "trace-file", usage: "output file for the file span exporter", set: func(c *Config, v string) error {
+		c.Tracing.File = v
+		return nil
+	}},
+	{name: "events-file", usage: "file booking events are appended to as JSON lines", set: func(c *Config, v string) error {
+		c.Events.File = v
+		return nil
+	}},
+	{name: "events-webhooks", usage: "comma separated URLs booking events are POSTed to", set: func(c *Config, v string) error {
+		c.Events.Webhooks = splitList(v)
+		return nil
+	}},
+	{name: "events-webhook-secret", usage: "secret the events POSTed to events-webhooks are signed with", set: func(c *Config, v string) error {
+		c.Events.WebhookSecret = v
+		return nil
+	}},
+	{name: "events-broker", usage: "publish booking events to the in-process broker", isBool: true, set: func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		c.Events.Broker = b
+		return err
+	}},
+	{name: "events-retry-interval", usage: "delay before retrying failed event deliveries", set: func(c *Config, v string) error {
+		return c.Events.RetryInterval.UnmarshalText([]byte(v))
+	}},
+	{name: "events-max-attempts", usage: "attempts before a sink is given up on for an event", set: func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		c.Events.MaxAttempts = n
+		return err
+	}},
+	{name: "events-webhook-store", usage: "file registered webhooks and queued deliveries are kept in; empty keeps them in memory", set: func(c *Config, v string) error {
+		c.Events.WebhookStore = v
+		return nil
+	}},
+	{name: "events-webhook-max-attempts", usage: "attempts before a webhook delivery is dead-lettered", set: func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		c.Events.WebhookMaxAttempts = n
+		return err
+	}},
+	{name: "notify-transport", usage: "passenger notifications: none, console, file, smtp or sms", set: func(c *Config, v string) error {
+		c.Notify.Transport = v
+		return nil
+	}},
+	{name: "notify-file", usage: "output file for the file notification transport", set: func(c *Config, v string) error {
+		c.Notify.File = v
+		return nil
+	}},
+	{name: "notify-smtp-addr", usage: "SMTP relay host:port", set: func(c *Config, v string) error {
+		c.Notify.SMTP.Addr = v
+		return nil
+	}},
+	{name: "notify-smtp-from", usage: "sender address for notification email", set: func(c *Config, v string) error {
+		c.Notify.SMTP.From = v
+		return nil
+	}},
+	{name: "notify-smtp-username", usage: "SMTP username; enables authentication", set: func(c *Config, v string) error {
+		c.Notify.SMTP.Username = v
+		return nil
+	}},
+	{name: "notify-smtp-password", usage: "SMTP password", set: func(c *Config, v string) error {
+		c.Notify.SMTP.Password = v
+		return nil
+	}},
+	{name: "notify-reminder-before", usage: "how long before departure reminders are sent", set: func(c *Config, v string) error {
+		return c.Notify.ReminderBefore.UnmarshalText([]byte(v))
+	}},
+	{name: "document-brands-dir", usage: "directory of ticket document brands, one subdirectory each", set: func(c *Config, v string) error {
+		c.Documents.BrandsDir = v
+		return nil
+	}},
+	{name: "departure", usage: "RFC 3339 departure time of the train; enables accessible seat release, no-shows and reminders", set: func(c *Config, v string) error {
+		c.Departure = v
+		return nil
+	}},
+	{name: "boarding-pass-key", usage: "PEM Ed25519 private key that signs boarding passes", set: func(c *Config, v string) error {
+		c.BoardingPassKey = v
+		return nil
+	}},
+	{name: "shutdown-timeout", usage: "how long in-flight RPCs may run after a shutdown signal", set: func(c *Config, v string) error {
+		return c.ShutdownTimeout.UnmarshalText([]byte(v))
+	}},
+	{name: "health-check-interval", usage: "how often dependencies are probed for health checks", set: func(c *Config, v string) error {
+		return c.HealthCheckInterval.UnmarshalText([]byte(v))
+	}},
+}
+
+// assignment is a raw value given for a setting on the command line.
+type assignment struct {
+	setting *setting
+	value   string
+}
+
+// flagValue records flag values so they can be applied after the file and
+// environment have been loaded.
+type flagValue struct {
+	setting *setting
+	values  *[]assignment
+}
+
+func (f flagValue) String() string   { return "" }
+func (f flagValue) IsBoolFlag() bool { return f.setting.isBool }
+func (f flagValue) Set(v string) error {
+	*f.values = append(*f.values, assignment{setting: f.setting, value: v})
+	return nil
+}
+
+// Load builds the configuration from args (without the program name). It
+// reports whether -print-config was requested so the caller can dump the
+// result and exit.
+func Load(args []string) (cfg *Config, printConfig bool, err error) {
+	fs := flag.NewFlagSet("ticketing-svc", flag.ContinueOnError)
+	file := fs.String("config", os.Getenv(envPrefix+"CONFIG"), "YAML or TOML configuration file")
+	fs.BoolVar(&printConfig, "print-config", false, "print the effective configuration and exit")
+
+	var flags []assignment
+	for i := range settings {
+		fs.Var(flagValue{setting: &settings[i], values: &flags}, settings[i].name, settings[i].usage)
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, false, err
+	}
+
+	cfg = Default()
+	if *file != "" {
+		if err := cfg.loadFile(*file); err != nil {
+			return nil, false, err
+		}
+	}
+
+	for i := range settings {
+		name := envName(settings[i].name)
+		if v, ok := os.LookupEnv(name); ok {
+			if err := settings[i].set(cfg, v); err != nil {
+				return nil, false, fmt.Errorf("config: %s: %w", name, err)
+			}
+		}
+	}
+
+	for _, a := range flags {
+		if err := a.setting.set(cfg, a.value); err != nil {
+			return nil, false, fmt.Errorf("config: -%s: %w", a.setting.name, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, false, fmt.Errorf("config: invalid configuration:\n%w", err)
+	}
+	return cfg, printConfig, nil
+}
+
+// loadFile decodes a YAML or TOML file, chosen by extension, over c.
+func (c *Config) loadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	switch filepath.Ext(name) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	case ".toml":
+		err = toml.Unmarshal(data, c)
+	default:
+		return fmt.Errorf("config: unsupported file type %q", name)
+	}
+	if err != nil {
+		return fmt.Errorf("config: parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// Print writes c to w as YAML with secrets redacted.
+func (c *Config) Print(w io.Writer) error {
+	redacted := *c
+	redacted.Auth.APIKeys = redact(c.Auth.APIKeys)
+	redacted.Auth.AdminAPIKeys = redact(c.Auth.AdminAPIKeys)
+	if c.Notify.SMTP.Password != "" {
+		redacted.Notify.SMTP.Password = "REDACTED"
+	}
+	if c.Events.WebhookSecret != "" {
+		redacted.Events.WebhookSecret = "REDACTED"
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&redacted); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// redact returns a list of the same length as keys with every key hidden.
+func redact(keys []string) []string {
+	redacted := make([]string, len(keys))
+	for i := range redacted {
+		redacted[i] = "REDACTED"
+	}
+	return redacted
+}
+
+// envName returns the environment variable overriding the named setting.
+func envName(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// splitList splits a comma separated list, dropping surrounding spaces.
+func splitList(v string) []string {
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// splitPairs parses a comma separated list of key=value pairs.
+func splitPairs(v string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, item := range splitList(v) {
+		key, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not a key=value pair", item)
+		}
+		pairs[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return pairs, nil
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "svc.yaml")
+	os.WriteFile(yamlFile, []byte("listen_addr: \":6000\"\nlog_level: debug\nseats:\n  sections: [A, B, C]\n  seats_per_section: 4\ntls:\n  reload_interval: 30s\n"), 0o600)
+	tomlFile := filepath.Join(dir, "svc.toml")
+	os.WriteFile(tomlFile, []byte("listen_addr = \":7000\"\n[rate_limit]\nrequests_per_second = 5.0\nburst = 10\n"), 0o600)
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		check   func(c *Config) bool
+		wantErr bool
+	}{
+		{
+			name:  "success - defaults",
+			check: func(c *Config) bool { return reflect.DeepEqual(c, Default()) },
+		},
+		{
+			name: "success - yaml file",
+			args: []string{"-config", yamlFile},
+			check: func(c *Config) bool {
+				return c.ListenAddr == ":6000" && c.LogLevel == "debug" && len(c.Seats.Sections) == 3 &&
+					c.Seats.SeatsPerSection == 4 && time.Duration(c.TLS.ReloadInterval) == 30*time.Second
+			},
+		},
+		{
+			name: "success - toml file",
+			args: []string{"-config", tomlFile},
+			check: func(c *Config) bool {
+				return c.ListenAddr == ":7000" && c.RateLimit.RequestsPerSecond == 5 && c.RateLimit.Burst == 10
+			},
+		},
+		{
+			name: "success - environment overrides file, flag overrides environment",
+			args: []string{"-config", yamlFile, "-listen-addr", ":9000"},
+			env:  map[string]string{"TICKETING_LISTEN_ADDR": ":8000", "TICKETING_SEATS_PER_SECTION": "2"},
+			check: func(c *Config) bool {
+				return c.ListenAddr == ":9000" && c.Seats.SeatsPerSection == 2 && c.LogLevel == "debug"
+			},
+		},
+		{
+			name: "success - seat classes and fares",
+			args: []string{"-seat-classes", "A=first", "-seat-fares", "first=80, standard=20"},
+			check: func(c *Config) bool {
+				return reflect.DeepEqual(c.Seats.Classes, map[string]string{"A": "first"}) &&
+					reflect.DeepEqual(c.Seats.Fares, map[string]float64{"first": 80, "standard": 20})
+			},
+		},
+		{
+			name: "success - event webhooks",
+			args: []string{"-events-webhooks", "http://a.example/hook, https://b.example/hook"},
+			env:  map[string]string{"TICKETING_EVENTS_WEBHOOK_SECRET": "s3cret"},
+			check: func(c *Config) bool {
+				return reflect.DeepEqual(c.Events.Webhooks, []string{"http://a.example/hook", "https://b.example/hook"}) &&
+					c.Events.WebhookSecret == "s3cret"
+			},
+		},
+		{
+			name:    "fail - event webhooks without a secret",
+			args:    []string{"-events-webhooks", "http://a.example/hook"},
+			wantErr: true,
+		},
+		{
+			name:  "success - departure",
+			args:  []string{"-departure", "2026-11-02T09:30:00Z"},
+			env:   map[string]string{"TICKETING_NOTIFY_TRANSPORT": "console"},
+			check: func(c *Config) bool { return c.Departure == "2026-11-02T09:30:00Z" && c.Notify.Transport == "console" },
+		},
+		{
+			name:    "fail - departure not RFC 3339",
+			args:    []string{"-departure", "2026-11-02 09:30"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - flush interval not positive",
+			args:    []string{"-storage-flush-interval", "0s"},
+			wantErr: true,
+		},
+		{
+			name:  "success - event broker",
+			args:  []string{"-events-broker"},
+			check: func(c *Config) bool { return c.Events.Broker },
+		},
+		{
+			name:    "fail - event webhook not a URL",
+			args:    []string{"-events-webhooks", "b.example/hook", "-events-webhook-secret", "s3cret"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - no event delivery attempts",
+			args:    []string{"-events-max-attempts", "0"},
+			wantErr: true,
+		},
+		{
+			name:  "success - sms transport",
+			args:  []string{"-notify-transport", "sms"},
+			check: func(c *Config) bool { return c.Notify.Transport == "sms" },
+		},
+		{
+			name:    "fail - smtp transport without relay",
+			args:    []string{"-notify-transport", "smtp"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - unknown quiet section",
+			args:    []string{"-seat-quiet-sections", "Z"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - blocked seat not on the train",
+			args:    []string{"-seat-blocked", "A-10"},
+			wantErr: true,
+		},
+		{
+			name:  "success - seat allocator",
+			args:  []string{"-seat-allocator", "best-fit"},
+			check: func(c *Config) bool { return c.Seats.Allocator == "best-fit" },
+		},
+		{
+			name:    "fail - unknown seat allocator",
+			args:    []string{"-seat-allocator", "random"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - wheelchair space not on the train",
+			args:    []string{"-seat-wheelchair-spaces", "Z-0"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - unknown seat class",
+			args:    []string{"-seat-classes", "A=premium"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - malformed fares",
+			args:    []string{"-seat-fares", "first"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - overbooking above 100 percent",
+			args:    []string{"-seat-overbooking", "A=150"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - invalid value",
+			args:    []string{"-seats-per-section", "many"},
+			wantErr: true,
+		},
+		{
+			name:    "fail - validation",
+			args:    []string{"-tls-require-client-cert", "-storage-backend", "etcd"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			got, _, err := Load(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.check != nil && !tt.check(got) {
+				t.Errorf("Load() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestConfig_Print(t *testing.T) {
+	c := Default()
+	c.Auth.APIKeys = []string{"secret-key"}
+	c.Auth.AdminAPIKeys = []string{"admin-key"}
+	c.Notify.SMTP.Password = "smtp-password"
+	c.Events.WebhookSecret = "webhook-secret"
+
+	var out strings.Builder
+	if err := c.Print(&out); err != nil {
+		t.Fatalf("Print() error = %v", err)
+	}
+	if strings.Contains(out.String(), "secret-key") || strings.Contains(out.String(), "admin-key") ||
+		strings.Contains(out.String(), "smtp-password") || strings.Contains(out.String(), "webhook-secret") {
+		t.Errorf("Print() leaked an API key:\n%s", out.String())
+	}
+	if c.Auth.APIKeys[0] != "secret-key" {
+		t.Error("Print() modified the configuration")
+	}
+}
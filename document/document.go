@@ -0,0 +1,162 @@
+// Package document renders tickets as printable PDF and HTML documents.
+// Each brand has its own name, colour, footer and HTML template.
+package document
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	train "ticketing-svc/proto"
+)
+
+// DefaultBrand is the brand used when none is requested.
+const DefaultBrand = "default"
+
+// ErrUnknownBrand is returned when rendering for a brand that is not loaded.
+var ErrUnknownBrand = errors.New("unknown brand")
+
+//go:embed brands
+var builtin embed.FS
+
+// Brand is the look of the tickets issued under one name. It is read from a
+// brand directory holding an optional brand.json with the fields below and
+// an optional ticket.html template; whatever is missing is taken from the
+// default brand.
+type Brand struct {
+	DisplayName string `json:"display_name"` // shown in the ticket header
+	Color       string `json:"color"`        // header colour, as #RRGGBB
+	Footer      string `json:"footer"`       // small print under the ticket
+
+	html *template.Template
+}
+
+// Renderer renders tickets for a set of brands.
+type Renderer struct {
+	brands map[string]*Brand
+}
+
+// Default returns a Renderer with only the built-in default brand.
+func Default() *Renderer {
+	sub, _ := fs.Sub(builtin, "brands/"+DefaultBrand)
+	brand, err := loadBrand(sub, nil)
+	if err != nil {
+		panic(err)
+	}
+	return &Renderer{brands: map[string]*Brand{DefaultBrand: brand}}
+}
+
+// NewRenderer returns a Renderer with the built-in default brand and one
+// brand per subdirectory of dir, named after it. A subdirectory called
+// "default" replaces the built-in brand. dir may be empty.
+func NewRenderer(dir string) (*Renderer, error) {
+	r := Default()
+	if dir == "" {
+		return r, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	base := r.brands[DefaultBrand]
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		brand, err := loadBrand(os.DirFS(filepath.Join(dir, entry.Name())), base)
+		if err != nil {
+			return nil, fmt.Errorf("brand %s: %w", entry.Name(), err)
+		}
+		r.brands[entry.Name()] = brand
+	}
+	return r, nil
+}
+
+var hexColor = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// loadBrand reads a brand from fsys, filling what it leaves out from base.
+func loadBrand(fsys fs.FS, base *Brand) (*Brand, error) {
+	brand := &Brand{}
+	if base != nil {
+		*brand = *base
+	}
+
+	data, err := fs.ReadFile(fsys, "brand.json")
+	if err == nil {
+		err = json.Unmarshal(data, brand)
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if !hexColor.MatchString(brand.Color) {
+		return nil, fmt.Errorf("color %q is not #RRGGBB", brand.Color)
+	}
+
+	html, err := fs.ReadFile(fsys, "ticket.html")
+	if err == nil {
+		brand.html, err = template.New("ticket.html").Parse(string(html))
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if brand.html == nil {
+		return nil, errors.New("no ticket.html template")
+	}
+	return brand, nil
+}
+
+// ticket is what the templates are executed with.
+type ticket struct {
+	Brand     *Brand
+	Passenger string
+	From      string
+	To        string
+	Seat      string
+	Price     string
+	Reference string
+}
+
+// ticketFor returns the template data for receipt under the named brand.
+func (r *Renderer) ticketFor(brandName string, receipt *train.Receipt) (ticket, error) {
+	if brandName == "" {
+		brandName = DefaultBrand
+	}
+	brand, ok := r.brands[brandName]
+	if !ok {
+		return ticket{}, fmt.Errorf("%w %q", ErrUnknownBrand, brandName)
+	}
+
+	user := receipt.GetUser()
+	return ticket{
+		Brand:     brand,
+		Passenger: strings.TrimSpace(user.GetFirstName() + " " + user.GetLastName()),
+		From:      receipt.GetFrom(),
+		To:        receipt.GetTo(),
+		Seat:      receipt.GetSeat(),
+		Price:     fmt.Sprintf("%.2f", receipt.GetPricePaid()),
+		Reference: receipt.GetBookingReference(),
+	}, nil
+}
+
+// HTML renders receipt as a standalone HTML page.
+func (r *Renderer) HTML(brand string, receipt *train.Receipt) ([]byte, error) {
+	t, err := r.ticketFor(brand, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := t.Brand.html.Execute(&out, t); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
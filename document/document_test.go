@@ -0,0 +1,80 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	train "ticketing-svc/proto"
+)
+
+func TestRenderer(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "express"), 0o755)
+	os.WriteFile(filepath.Join(dir, "express", "brand.json"), []byte(`{"display_name": "Express Rail", "color": "#C00000"}`), 0o600)
+	os.MkdirAll(filepath.Join(dir, "plain"), 0o755)
+	os.WriteFile(filepath.Join(dir, "plain", "ticket.html"), []byte(`{{.Passenger}} / {{.Seat}} / {{.Reference}}`), 0o600)
+
+	r, err := NewRenderer(dir)
+	if err != nil {
+		t.Fatalf("NewRenderer() error = %v", err)
+	}
+	receipt := &train.Receipt{
+		From:             "London",
+		To:               "France",
+		User:             &train.User{FirstName: "Zoë", LastName: "<Doe>", Email: "zoe.doe@example.com"},
+		PricePaid:        20,
+		Seat:             "A-3",
+		BookingReference: "K7QX2MNP",
+	}
+
+	tests := []struct {
+		name     string
+		brand    string
+		wantHTML []string
+		wantErr  error
+	}{
+		{
+			name:     "success - default brand",
+			wantHTML: []string{"Train Tickets", "Zoë &lt;Doe&gt;", "London", "France", "A-3", "20.00", "K7QX2MNP"},
+		},
+		{
+			name:     "success - brand settings over default template",
+			brand:    "express",
+			wantHTML: []string{"Express Rail", "#C00000", "K7QX2MNP"},
+		},
+		{
+			name:     "success - brand template",
+			brand:    "plain",
+			wantHTML: []string{"Zoë &lt;Doe&gt; / A-3 / K7QX2MNP"},
+		},
+		{
+			name:    "fail - unknown brand",
+			brand:   "missing",
+			wantErr: ErrUnknownBrand,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := r.HTML(tt.brand, receipt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Renderer.HTML() error = %v, want %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantHTML {
+				if !bytes.Contains(html, []byte(want)) {
+					t.Errorf("Renderer.HTML() = %s, want it to contain %q", html, want)
+				}
+			}
+
+			pdf, err := r.PDF(tt.brand, receipt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Renderer.PDF() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !bytes.HasPrefix(pdf, []byte("%PDF-")) {
+				t.Errorf("Renderer.PDF() = %.20q..., want a PDF", pdf)
+			}
+		})
+	}
+}
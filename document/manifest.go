@@ -0,0 +1,120 @@
+package document
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// ManifestEntry is one passenger on a manifest.
+type ManifestEntry struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Seat    string `json:"seat"`
+	Section string `json:"section"`
+	Status  string `json:"status"`
+}
+
+// manifestColumns are the manifest fields in column order, with their width
+// in millimetres on the PDF table.
+var manifestColumns = []struct {
+	title string
+	width float64
+	value func(ManifestEntry) string
+}{
+	{"Name", 55, func(e ManifestEntry) string { return e.Name }},
+	{"Email", 65, func(e ManifestEntry) string { return e.Email }},
+	{"Seat", 18, func(e ManifestEntry) string { return e.Seat }},
+	{"Section", 20, func(e ManifestEntry) string { return e.Section }},
+	{"Status", 32, func(e ManifestEntry) string { return e.Status }},
+}
+
+// ManifestCSV writes entries to w as CSV with a header row.
+func ManifestCSV(w io.Writer, entries []ManifestEntry) error {
+	out := csv.NewWriter(w)
+	row := make([]string, len(manifestColumns))
+	for i, col := range manifestColumns {
+		row[i] = col.title
+	}
+	out.Write(row)
+	for _, entry := range entries {
+		for i, col := range manifestColumns {
+			row[i] = col.value(entry)
+		}
+		out.Write(row)
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// ManifestJSONLines writes entries to w as one JSON object per line.
+func ManifestJSONLines(w io.Writer, entries []ManifestEntry) error {
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := enc.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ManifestPDF writes entries to w as an A4 table headed with title in the
+// default brand's colours. The column headings repeat on every page.
+func (r *Renderer) ManifestPDF(w io.Writer, title string, entries []ManifestEntry) error {
+	brand := r.brands[DefaultBrand]
+	red, green, blue := rgb(brand.Color)
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(brand.DisplayName+" "+title, true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(10, 10, 10)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AliasNbPages("")
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetTextColor(red, green, blue)
+		pdf.SetFont("Helvetica", "B", 16)
+		pdf.CellFormat(0, 10, tr(brand.DisplayName+" - "+title), "", 1, "L", false, 0, "")
+		pdf.SetFillColor(red, green, blue)
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont("Helvetica", "B", 10)
+		for _, col := range manifestColumns {
+			pdf.CellFormat(col.width, 7, col.title, "", 0, "L", true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetTextColor(30, 30, 30)
+		pdf.SetFont("Helvetica", "", 10)
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetTextColor(110, 110, 110)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%d passengers - page %d of {nb}", len(entries), pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	for i, entry := range entries {
+		// Shade alternate rows so lines are easy to follow across the page.
+		pdf.SetFillColor(240, 240, 240)
+		for _, col := range manifestColumns {
+			pdf.CellFormat(col.width, 6, fit(pdf, tr(col.value(entry)), col.width-2), "", 0, "L", i%2 == 1, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	return pdf.Output(w)
+}
+
+// fit shortens text with an ellipsis until it is at most width wide in the
+// current font.
+func fit(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+	return text + "..."
+}
@@ -0,0 +1,80 @@
+package document
+
+import (
+	"bytes"
+	"strconv"
+
+	train "ticketing-svc/proto"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// PDF renders receipt as a single A5 landscape page.
+func (r *Renderer) PDF(brand string, receipt *train.Receipt) ([]byte, error) {
+	t, err := r.ticketFor(brand, receipt)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf := gofpdf.New("L", "mm", "A5", "")
+	pdf.SetTitle(t.Brand.DisplayName+" ticket "+t.Reference, true)
+	// The core fonts only cover Windows-1252, so translate from UTF-8.
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(false, 0)
+	pdf.AddPage()
+
+	width, _ := pdf.GetPageSize()
+	red, green, blue := rgb(t.Brand.Color)
+
+	// Header band in the brand colour.
+	pdf.SetFillColor(red, green, blue)
+	pdf.Rect(0, 0, width, 25, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 20)
+	pdf.SetXY(15, 8)
+	pdf.CellFormat(0, 10, tr(t.Brand.DisplayName), "", 1, "L", false, 0, "")
+
+	rows := []struct{ label, value string }{
+		{"Passenger", t.Passenger},
+		{"From", t.From},
+		{"To", t.To},
+		{"Seat", t.Seat},
+		{"Price paid", t.Price},
+	}
+	pdf.SetY(35)
+	for _, row := range rows {
+		pdf.SetTextColor(110, 110, 110)
+		pdf.SetFont("Helvetica", "", 12)
+		pdf.CellFormat(45, 9, tr(row.label), "", 0, "L", false, 0, "")
+		pdf.SetTextColor(30, 30, 30)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(0, 9, tr(row.value), "", 1, "L", false, 0, "")
+	}
+
+	pdf.Ln(4)
+	pdf.SetTextColor(110, 110, 110)
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.CellFormat(45, 12, "Booking reference", "", 0, "L", false, 0, "")
+	pdf.SetTextColor(red, green, blue)
+	pdf.SetFont("Courier", "B", 22)
+	pdf.CellFormat(0, 12, t.Reference, "", 1, "L", false, 0, "")
+
+	_, height := pdf.GetPageSize()
+	pdf.SetXY(15, height-20)
+	pdf.SetTextColor(110, 110, 110)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(0, 4.5, tr(t.Brand.Footer), "", "L", false)
+
+	var out bytes.Buffer
+	if err := pdf.Output(&out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// rgb splits a validated #RRGGBB colour into its components.
+func rgb(color string) (int, int, int) {
+	v, _ := strconv.ParseUint(color[1:], 16, 32)
+	return int(v >> 16 & 0xff), int(v >> 8 & 0xff), int(v & 0xff)
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+)
+
+// Dispatcher delivers the events in an outbox to every sink.
+type Dispatcher struct {
+	outbox      *Outbox
+	maxAttempts int
+	sinks       []Sink
+}
+
+// NewDispatcher returns a Dispatcher delivering the events in outbox to
+// sinks. A sink that fails maxAttempts times on an event is given up on for
+// that event, so it cannot hold back the events after it forever.
+func NewDispatcher(outbox *Outbox, maxAttempts int, sinks ...Sink) *Dispatcher {
+	return &Dispatcher{outbox: outbox, maxAttempts: maxAttempts, sinks: sinks}
+}
+
+// Run delivers events as checkpoints holding them are saved, retrying failed
+// deliveries every retryInterval, until ctx is done. Events still pending
+// when it returns stay in the outbox.
+func (d *Dispatcher) Run(ctx context.Context, retryInterval time.Duration) {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Deliver(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("event delivery failed, retrying in %v: %v", retryInterval, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-d.outbox.notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver makes one pass over the saved part of the outbox, sending each
+// pending event to the sinks that have not acknowledged it yet. Events reach
+// each sink in the order they were added: after a failure, a sink gets
+// nothing more until the next pass, unless that was its last attempt at the
+// event, which is then logged and skipped. Fully acknowledged events are
+// removed from the outbox.
+func (d *Dispatcher) Deliver(ctx context.Context) error {
+	var errs []error
+	failed := make(map[string]bool)
+
+	for _, entry := range d.outbox.Committed() {
+		done := true
+		for _, sink := range d.sinks {
+			name := sink.Name()
+			if slices.Contains(entry.Delivered, name) {
+				continue
+			}
+			if failed[name] {
+				done = false
+				continue
+			}
+			if err := sink.Deliver(ctx, entry.Event); err != nil {
+				errs = append(errs, fmt.Errorf("%s: event %s: %w", name, entry.Event.ID, err))
+				if d.outbox.markFailed(entry.Event.ID, name) < d.maxAttempts {
+					failed[name] = true
+					done = false
+					continue
+				}
+				log.Printf("event %s dropped for %s after %d attempts", entry.Event.ID, name, d.maxAttempts)
+			}
+			d.outbox.markDelivered(entry.Event.ID, name)
+		}
+		if done {
+			d.outbox.remove(entry.Event.ID)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+// Package events defines the booking lifecycle events emitted by the ticket
+// service and delivers them to sinks, at least once, through an outbox that
+// is persisted together with the bookings.
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	train "ticketing-svc/proto"
+)
+
+// Type identifies the kind of booking change an event describes.
+type Type string
+
+const (
+	// TicketPurchased is emitted when a ticket is issued.
+	TicketPurchased Type = "ticket.purchased"
+	// SeatModified is emitted when a passenger moves to another seat.
+	SeatModified Type = "seat.modified"
+	// TicketCancelled is emitted when a passenger is removed from the train.
+	TicketCancelled Type = "ticket.cancelled"
+	// WaitlistPromoted is emitted when a passenger whose ticket was sold
+	// beyond capacity is given a seat.
+	WaitlistPromoted Type = "waitlist.promoted"
+)
+
+// Types lists every event type.
+var Types = []Type{TicketPurchased, SeatModified, TicketCancelled, WaitlistPromoted}
+
+// Event is a single booking change. Consumers may receive an event more than
+// once and should use ID to discard duplicates.
+type Event struct {
+	ID           string    `json:"id"`
+	Type         Type      `json:"type"`
+	Time         time.Time `json:"time"`
+	Email        string    `json:"email"`
+	FirstName    string    `json:"first_name,omitempty"`
+	LastName     string    `json:"last_name,omitempty"`
+	From         string    `json:"from,omitempty"`
+	To           string    `json:"to,omitempty"`
+	Seat         string    `json:"seat,omitempty"`
+	PreviousSeat string    `json:"previous_seat,omitempty"` // SeatModified only
+	PricePaid    float64   `json:"price_paid,omitempty"`
+	Reference    string    `json:"booking_reference,omitempty"`
+}
+
+// FromReceipt returns a new event of type t describing the ticket in r.
+func FromReceipt(t Type, r *train.Receipt) Event {
+	return Event{
+		ID:        NewID(),
+		Type:      t,
+		Time:      time.Now().UTC(),
+		Email:     r.GetUser().GetEmail(),
+		FirstName: r.GetUser().GetFirstName(),
+		LastName:  r.GetUser().GetLastName(),
+		From:      r.GetFrom(),
+		To:        r.GetTo(),
+		Seat:      r.GetSeat(),
+		PricePaid: r.GetPricePaid(),
+		Reference: r.GetBookingReference(),
+	}
+}
+
+// NewID returns a random 128-bit identifier in hex, as used for event IDs.
+func NewID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
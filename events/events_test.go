@@ -0,0 +1,188 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	train "ticketing-svc/proto"
+)
+
+// flakySink fails while err is set and records what it accepted.
+type flakySink struct {
+	err  error
+	got  []string
+	name string
+}
+
+func (s *flakySink) Name() string { return s.name }
+func (s *flakySink) Deliver(ctx context.Context, e Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.got = append(s.got, e.Email)
+	return nil
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	outbox := NewOutbox()
+	good := &flakySink{name: "good"}
+	flaky := &flakySink{name: "flaky", err: errors.New("unreachable")}
+	d := NewDispatcher(outbox, 3, good, flaky)
+
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		outbox.Add(FromReceipt(TicketPurchased, &train.Receipt{User: &train.User{Email: email}}))
+	}
+
+	tests := []struct {
+		name      string
+		save      bool // save a checkpoint of the outbox first
+		flakyErr  error
+		wantGood  []string
+		wantFlaky []string
+		pending   int
+		wantErr   bool
+	}{
+		{
+			name:    "success - nothing sent before a checkpoint is saved",
+			pending: 2,
+		},
+		{
+			name:     "fail - one sink down",
+			save:     true,
+			flakyErr: errors.New("unreachable"),
+			wantGood: []string{"a@example.com", "b@example.com"},
+			pending:  2,
+			wantErr:  true,
+		},
+		{
+			name:      "success - retry reaches only the failed sink",
+			wantGood:  []string{"a@example.com", "b@example.com"},
+			wantFlaky: []string{"a@example.com", "b@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.save {
+				_, version := outbox.Checkpoint()
+				outbox.Saved(version)
+			}
+			flaky.err = tt.flakyErr
+			err := d.Deliver(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Dispatcher.Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(good.got, tt.wantGood) {
+				t.Errorf("good sink got %v, want %v", good.got, tt.wantGood)
+			}
+			if !reflect.DeepEqual(flaky.got, tt.wantFlaky) {
+				t.Errorf("flaky sink got %v, want %v", flaky.got, tt.wantFlaky)
+			}
+			if got := len(outbox.Entries()); got != tt.pending {
+				t.Errorf("pending entries = %d, want %d", got, tt.pending)
+			}
+		})
+	}
+}
+
+func TestDispatcher_Deliver_maxAttempts(t *testing.T) {
+	outbox := NewOutbox()
+	good := &flakySink{name: "good"}
+	down := &flakySink{name: "down", err: errors.New("unreachable")}
+	d := NewDispatcher(outbox, 2, good, down)
+
+	for _, email := range []string{"a@example.com", "b@example.com"} {
+		outbox.Add(FromReceipt(TicketPurchased, &train.Receipt{User: &train.User{Email: email}}))
+	}
+	_, version := outbox.Checkpoint()
+	outbox.Saved(version)
+
+	// The sink that is down holds back b until it has been given up on for
+	// a, and neither event stays in the outbox for good.
+	for pass, want := range []int{2, 1, 0} {
+		if err := d.Deliver(context.Background()); err == nil {
+			t.Errorf("pass %d: Dispatcher.Deliver() error = nil", pass)
+		}
+		if got := len(outbox.Entries()); got != want {
+			t.Errorf("pass %d: pending entries = %d, want %d", pass, got, want)
+		}
+	}
+	if want := []string{"a@example.com", "b@example.com"}; !reflect.DeepEqual(good.got, want) {
+		t.Errorf("good sink got %v, want %v", good.got, want)
+	}
+}
+
+func TestSinks(t *testing.T) {
+	e := FromReceipt(SeatModified, &train.Receipt{User: &train.User{Email: "john.doe@example.com"}, Seat: "B-3"})
+	e.PreviousSeat = "A-0"
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		sink, err := NewFileSink(path)
+		if err != nil {
+			t.Fatalf("NewFileSink() error = %v", err)
+		}
+		defer sink.Close()
+		if err := sink.Deliver(context.Background(), e); err != nil {
+			t.Fatalf("FileSink.Deliver() error = %v", err)
+		}
+
+		f, _ := os.Open(path)
+		defer f.Close()
+		sc := bufio.NewScanner(f)
+		sc.Scan()
+		var got Event
+		if err := json.Unmarshal(sc.Bytes(), &got); err != nil || got != e {
+			t.Errorf("file line = %s, want %+v", sc.Text(), e)
+		}
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		var got Event
+		var signed bool
+		status := http.StatusNoContent
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			signed = Verify("s3cret", r.Header.Get(SignatureHeader), r.Header.Get(TimestampHeader), body, time.Minute)
+			json.Unmarshal(body, &got)
+			w.WriteHeader(status)
+		}))
+		defer srv.Close()
+
+		sink := NewWebhookSink(srv.URL, "s3cret", nil)
+		if err := sink.Deliver(context.Background(), e); err != nil || got != e || !signed {
+			t.Errorf("WebhookSink.Deliver() error = %v, posted %+v, signed %v", err, got, signed)
+		}
+		status = http.StatusBadGateway
+		if err := sink.Deliver(context.Background(), e); err == nil {
+			t.Errorf("WebhookSink.Deliver() on %d error = nil", status)
+		}
+	})
+
+	t.Run("broker", func(t *testing.T) {
+		b := NewBroker()
+		ch, cancel := b.Subscribe(1)
+		if err := b.Deliver(context.Background(), e); err != nil {
+			t.Fatalf("Broker.Deliver() error = %v", err)
+		}
+		if got := <-ch; got != e {
+			t.Errorf("subscriber got %+v, want %+v", got, e)
+		}
+
+		// A full subscriber that unsubscribes no longer holds up delivery.
+		b.Deliver(context.Background(), e)
+		cancel()
+		if err := b.Deliver(context.Background(), e); err != nil {
+			t.Errorf("Broker.Deliver() after unsubscribe error = %v", err)
+		}
+	})
+}
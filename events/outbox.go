@@ -0,0 +1,172 @@
+package events
+
+import "sync"
+
+// Entry is an event waiting in the outbox together with the sinks that have
+// already acknowledged it or given up on it, and the failed attempts of the
+// others.
+type Entry struct {
+	Event     Event          `json:"event"`
+	Delivered []string       `json:"delivered,omitempty"`
+	Attempts  map[string]int `json:"attempts,omitempty"` // failed deliveries by sink
+
+	added uint64 // outbox version the event was added at
+}
+
+// clone returns a copy of e that shares nothing with it.
+func (e Entry) clone() Entry {
+	c := Entry{Event: e.Event, Delivered: append([]string(nil), e.Delivered...)}
+	if e.Attempts != nil {
+		c.Attempts = make(map[string]int, len(e.Attempts))
+		for sink, n := range e.Attempts {
+			c.Attempts[sink] = n
+		}
+	}
+	return c
+}
+
+// Outbox holds events until every sink has acknowledged them. The ticket
+// service adds events while it holds its booking lock and saves the pending
+// entries in the same snapshot as the bookings, so an event is never lost
+// for a change that was persisted. Events are only handed to the dispatcher
+// once a checkpoint holding them is saved, so none is sent for a change that
+// could still be lost.
+type Outbox struct {
+	mu      sync.Mutex // protects the following fields
+	entries []Entry
+	version uint64 // incremented on every change
+	saved   uint64 // version of the last saved checkpoint
+
+	notify chan struct{} // signalled when a checkpoint with new events is saved
+}
+
+// NewOutbox returns an empty outbox.
+func NewOutbox() *Outbox {
+	return &Outbox{notify: make(chan struct{}, 1)}
+}
+
+// Add appends e to the outbox. It is delivered once a checkpoint holding it
+// is saved.
+func (o *Outbox) Add(e Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.version++
+	o.entries = append(o.entries, Entry{Event: e, added: o.version})
+}
+
+// Restore replaces the pending entries with those from a saved checkpoint.
+func (o *Outbox) Restore(entries []Entry) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.entries = append([]Entry(nil), entries...)
+	o.version++
+	o.saved = o.version
+}
+
+// Entries returns a copy of the pending entries, oldest first.
+func (o *Outbox) Entries() []Entry {
+	entries, _ := o.Checkpoint()
+	return entries
+}
+
+// Committed returns a copy of the pending entries covered by the last saved
+// checkpoint, oldest first. These are the ones that may be delivered.
+func (o *Outbox) Committed() []Entry {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var entries []Entry
+	for _, e := range o.entries {
+		if e.added > o.saved {
+			break
+		}
+		entries = append(entries, e.clone())
+	}
+	return entries
+}
+
+// Checkpoint returns a copy of the pending entries and the version they
+// correspond to, to be passed to Saved once they are persisted.
+func (o *Outbox) Checkpoint() ([]Entry, uint64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	entries := make([]Entry, len(o.entries))
+	for i, e := range o.entries {
+		entries[i] = e.clone()
+	}
+	return entries, o.version
+}
+
+// Saved records that the checkpoint at version has been persisted and wakes
+// up the dispatcher to deliver the events it holds.
+func (o *Outbox) Saved(version uint64) {
+	o.mu.Lock()
+	if version > o.saved {
+		o.saved = version
+	}
+	o.mu.Unlock()
+
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Unsaved reports whether the outbox changed since the last saved
+// checkpoint.
+func (o *Outbox) Unsaved() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.version != o.saved
+}
+
+// markDelivered records that sink acknowledged the event with id.
+func (o *Outbox) markDelivered(id, sink string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].Event.ID == id {
+			o.entries[i].Delivered = append(o.entries[i].Delivered, sink)
+			o.version++
+			return
+		}
+	}
+}
+
+// markFailed records a failed delivery of the event with id to sink and
+// returns how many times delivering it there has failed.
+func (o *Outbox) markFailed(id, sink string) int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].Event.ID == id {
+			if o.entries[i].Attempts == nil {
+				o.entries[i].Attempts = make(map[string]int)
+			}
+			o.entries[i].Attempts[sink]++
+			o.version++
+			return o.entries[i].Attempts[sink]
+		}
+	}
+	return 0
+}
+
+// remove drops the event with id once every sink has acknowledged it.
+func (o *Outbox) remove(id string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := range o.entries {
+		if o.entries[i].Event.ID == id {
+			o.entries = append(o.entries[:i], o.entries[i+1:]...)
+			o.version++
+			return
+		}
+	}
+}
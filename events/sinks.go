@@ -0,0 +1,186 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sink receives events from a Dispatcher. Deliver returns nil only once the
+// event has been durably accepted; otherwise it is retried later.
+type Sink interface {
+	// Name identifies the sink in the outbox, so it must stay the same
+	// across restarts.
+	Name() string
+	Deliver(ctx context.Context, e Event) error
+}
+
+// FileSink appends events to a file as JSON lines.
+type FileSink struct {
+	mu   sync.Mutex // serialises writes
+	path string
+	f    *os.File
+}
+
+// NewFileSink opens path for appending, creating it if needed.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{path: path, f: f}, nil
+}
+
+// Name implements Sink.
+func (s *FileSink) Name() string { return "file:" + s.path }
+
+// Deliver writes e as one line and syncs the file.
+func (s *FileSink) Deliver(ctx context.Context, e Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+// Close closes the underlying file.
+func (s *FileSink) Close() error {
+	return s.f.Close()
+}
+
+// WebhookSink POSTs each event as JSON to a URL, signed with a secret.
+type WebhookSink struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting events to url, signed with secret,
+// with client, or with http.DefaultClient when client is nil.
+func NewWebhookSink(url, secret string, client *http.Client) *WebhookSink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &WebhookSink{url: url, secret: secret, client: client}
+}
+
+// Name implements Sink.
+func (s *WebhookSink) Name() string { return "webhook:" + s.url }
+
+// Deliver posts e and succeeds on any 2xx response.
+func (s *WebhookSink) Deliver(ctx context.Context, e Event) error {
+	return Post(ctx, s.client, s.url, s.secret, e)
+}
+
+// Post sends e as JSON to url with client, signed with secret in the
+// SignatureHeader and TimestampHeader headers, and succeeds on any 2xx
+// response.
+func Post(ctx context.Context, client *http.Client, url, secret string, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", e.ID)
+	req.Header.Set("X-Event-Type", string(e.Type))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(SignatureHeader, Sign(secret, now, body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Broker is an in-process publish/subscribe sink standing in for a message
+// broker such as NATS or Kafka. Every subscriber receives every event.
+type Broker struct {
+	mu     sync.Mutex // protects the following fields
+	subs   map[int]*subscription
+	nextID int
+}
+
+// subscription is one Broker subscriber.
+type subscription struct {
+	events chan Event
+	done   chan struct{} // closed when the subscription ends
+}
+
+// NewBroker returns a Broker with no subscribers.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[int]*subscription)}
+}
+
+// Name implements Sink.
+func (b *Broker) Name() string { return "broker" }
+
+// Subscribe returns a channel receiving events published after the call,
+// with room for buffer events, and a function that ends the subscription.
+// The channel is not closed when the subscription ends.
+func (b *Broker) Subscribe(buffer int) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	sub := &subscription{events: make(chan Event, buffer), done: make(chan struct{})}
+	b.subs[id] = sub
+
+	var once sync.Once
+	return sub.events, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, id)
+			b.mu.Unlock()
+			close(sub.done)
+		})
+	}
+}
+
+// Deliver hands e to every subscriber, waiting for room in their buffers
+// until ctx is done.
+func (b *Broker) Deliver(ctx context.Context, e Event) error {
+	b.mu.Lock()
+	subs := make([]*subscription, 0, len(b.subs))
+	for _, sub := range b.subs {
+		subs = append(subs, sub)
+	}
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		select {
+		case sub.events <- e:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package gateway
+
+import (
+	"net/http"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestCodeName(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       codes.Code
+		want       string
+		wantStatus int
+	}{
+		{name: "success - ok", code: codes.OK, want: "OK", wantStatus: http.StatusOK},
+		{name: "success - cancelled spelled as in code.proto", code: codes.Canceled, want: "CANCELLED", wantStatus: 499},
+		{name: "success - several words", code: codes.FailedPrecondition, want: "FAILED_PRECONDITION", wantStatus: http.StatusBadRequest},
+		{name: "success - not found", code: codes.NotFound, want: "NOT_FOUND", wantStatus: http.StatusNotFound},
+		{name: "success - unauthenticated", code: codes.Unauthenticated, want: "UNAUTHENTICATED", wantStatus: http.StatusUnauthorized},
+		{name: "success - data loss", code: codes.DataLoss, want: "DATA_LOSS", wantStatus: http.StatusInternalServerError},
+		{name: "fail - code outside code.proto", code: codes.Code(42), want: "UNKNOWN", wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := codeName(tt.code); got != tt.want {
+				t.Errorf("codeName(%v) = %q, want %q", tt.code, got, tt.want)
+			}
+			if got := HTTPStatusFromCode(tt.code); got != tt.wantStatus {
+				t.Errorf("HTTPStatusFromCode(%v) = %d, want %d", tt.code, got, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+// Package gateway exposes the TicketService over HTTP/JSON for clients that
+// cannot speak gRPC, translating each route into a call on a
+// TicketServiceClient and gRPC status codes into HTTP errors.
+package gateway
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"ticketing-svc/middleware"
+	train "ticketing-svc/proto"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxBodyBytes bounds the size of request bodies.
+const maxBodyBytes = 1 << 20
+
+var (
+	marshaler   = protojson.MarshalOptions{}
+	unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// Route describes one HTTP operation of the gateway.
+type Route struct {
+	Method  string
+	Pattern string   // path with {param} placeholders named after request fields
+	RPC     string   // TicketService method the route calls
+	Body    bool     // the request message is read from the JSON body
+	Query   []string // request fields that may be set from the query string
+	handler func(g *Gateway, w http.ResponseWriter, r *http.Request, params map[string]string)
+}
+
+// Routes lists every HTTP operation in the order they are matched.
+var Routes = []Route{
+	{Method: http.MethodPost, Pattern: "/tickets", RPC: "PurchaseTicket", Body: true, handler: (*Gateway).purchaseTicket},
+	{Method: http.MethodGet, Pattern: "/tickets/{email}", RPC: "GetReceipt", handler: (*Gateway).getReceipt},
+	{Method: http.MethodDelete, Pattern: "/tickets/{email}", RPC: "RemoveUser", handler: (*Gateway).removeUser},
+	{Method: http.MethodPost, Pattern: "/tickets/{email}/check-in", RPC: "CheckIn", handler: (*Gateway).checkIn},
+	{Method: http.MethodPost, Pattern: "/tickets/{email}/board", RPC: "Board", handler: (*Gateway).board},
+	{Method: http.MethodPost, Pattern: "/tickets/{email}/upgrade", RPC: "UpgradeTicket", Body: true, handler: (*Gateway).upgradeTicket},
+	{Method: http.MethodPatch, Pattern: "/tickets/{email}/seat", RPC: "ModifySeat", Body: true, handler: (*Gateway).modifySeat},
+	{Method: http.MethodGet, Pattern: "/tickets/{email}/document", RPC: "GetTicketDocument", Query: ticketDocumentQuery, handler: (*Gateway).getTicketDocument},
+	{Method: http.MethodGet, Pattern: "/tickets/{email}/boarding-pass", RPC: "GetBoardingPass", handler: (*Gateway).getBoardingPass},
+	{Method: http.MethodPost, Pattern: "/boarding-passes/validate", RPC: "ValidateBoardingPass", Body: true, handler: (*Gateway).validateBoardingPass},
+	{Method: http.MethodGet, Pattern: "/sections/{section}/seats", RPC: "ViewSeats", Query: viewSeatsQuery, handler: (*Gateway).viewSeats},
+	{Method: http.MethodGet, Pattern: "/journeys/{journey}/seat-map", RPC: "GetSeatMap", handler: (*Gateway).getSeatMap},
+}
+
+// viewSeatsQuery lists the ViewSeats filters and paging fields accepted in
+// the query string.
+var viewSeatsQuery = []string{"states", "class", "page_size", "page_token"}
+
+// ticketDocumentQuery lists the GetTicketDocument fields accepted in the
+// query string.
+var ticketDocumentQuery = []string{"format", "brand"}
+
+// SpecPath is where the OpenAPI document describing Routes is served.
+const SpecPath = "/openapi.json"
+
+// spec is generated from ticketing.proto and Routes by cmd/openapi-gen.
+//
+//go:generate go run ../cmd/openapi-gen -root .. -proto proto/ticketing.proto -out openapi.json
+//go:embed openapi.json
+var spec []byte
+
+// Gateway is an http.Handler serving Routes.
+type Gateway struct {
+	client train.TicketServiceClient
+}
+
+// New returns a Gateway forwarding requests to client.
+func New(client train.TicketServiceClient) *Gateway {
+	return &Gateway{client: client}
+}
+
+// ServeHTTP implements http.Handler.
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == SpecPath && r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+		return
+	}
+
+	pathMatched := false
+	for _, route := range Routes {
+		params, ok := match(route.Pattern, r.URL.EscapedPath())
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if route.Method == r.Method {
+			route.handler(g, w, r, params)
+			return
+		}
+	}
+
+	if pathMatched {
+		writeError(w, http.StatusMethodNotAllowed, status.New(codes.Unimplemented, "method not allowed"))
+		return
+	}
+	writeError(w, http.StatusNotFound, status.New(codes.NotFound, "no route for "+r.URL.Path))
+}
+
+func (g *Gateway) purchaseTicket(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	in := &train.PurchaseRequest{}
+	if !decode(w, r, in) {
+		return
+	}
+	resp, err := g.client.PurchaseTicket(outgoing(r), in)
+	respond(w, resp, err)
+}
+
+func (g *Gateway) getReceipt(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	resp, err := g.client.GetReceipt(outgoing(r), &train.UserRequest{Email: params["email"]})
+	respond(w, resp, err)
+}
+
+func (g *Gateway) removeUser(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	resp, err := g.client.RemoveUser(outgoing(r), &train.UserRequest{Email: params["email"]})
+	respond(w, resp, err)
+}
+
+func (g *Gateway) checkIn(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	resp, err := g.client.CheckIn(outgoing(r), &train.UserRequest{Email: params["email"]})
+	respond(w, resp, err)
+}
+
+func (g *Gateway) board(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	resp, err := g.client.Board(outgoing(r), &train.UserRequest{Email: params["email"]})
+	respond(w, resp, err)
+}
+
+func (g *Gateway) upgradeTicket(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in := &train.UpgradeRequest{}
+	if !decode(w, r, in) {
+		return
+	}
+	in.Email = params["email"]
+	resp, err := g.client.UpgradeTicket(outgoing(r), in)
+	respond(w, resp, err)
+}
+
+func (g *Gateway) modifySeat(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in := &train.ModifySeatRequest{}
+	if !decode(w, r, in) {
+		return
+	}
+	// The path identifies the ticket; an email in the body is ignored.
+	in.Email = params["email"]
+	resp, err := g.client.ModifySeat(outgoing(r), in)
+	respond(w, resp, err)
+}
+
+func (g *Gateway) getTicketDocument(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in := &train.TicketDocumentRequest{}
+	if !decodeQuery(w, r, in, ticketDocumentQuery...) {
+		return
+	}
+	in.Email = params["email"]
+	resp, err := g.client.GetTicketDocument(outgoing(r), in)
+	respond(w, resp, err)
+}
+
+func (g *Gateway) getBoardingPass(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	resp, err := g.client.GetBoardingPass(outgoing(r), &train.UserRequest{Email: params["email"]})
+	respond(w, resp, err)
+}
+
+func (g *Gateway) validateBoardingPass(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	in := &train.ValidateBoardingPassRequest{}
+	if !decode(w, r, in) {
+		return
+	}
+	resp, err := g.client.ValidateBoardingPass(outgoing(r), in)
+	respond(w, resp, err)
+}
+
+func (g *Gateway) viewSeats(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	in := &train.SectionRequest{}
+	if !decodeQuery(w, r, in, viewSeatsQuery...) {
+		return
+	}
+	in.Section = params["section"]
+	resp, err := g.client.ViewSeats(outgoing(r), in)
+	respond(w, resp, err)
+}
+
+func (g *Gateway) getSeatMap(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	resp, err := g.client.GetSeatMap(outgoing(r), &train.SeatMapRequest{Journey: params["journey"]})
+	respond(w, resp, err)
+}
+
+// match reports whether path matches pattern, returning the unescaped
+// values of its {param} segments.
+func match(pattern, path string) (map[string]string, bool) {
+	want := strings.Split(strings.Trim(pattern, "/"), "/")
+	got := strings.Split(strings.Trim(path, "/"), "/")
+	if len(want) != len(got) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range want {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			value, err := url.PathUnescape(got[i])
+			if err != nil || value == "" {
+				return nil, false
+			}
+			params[strings.Trim(segment, "{}")] = value
+			continue
+		}
+		if segment != got[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// outgoing builds the context for the gRPC call, forwarding the caller's API
+// key and trace context.
+func outgoing(r *http.Request) context.Context {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	if key := r.Header.Get(middleware.APIKeyHeader); key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, middleware.APIKeyHeader, key)
+	}
+	return ctx
+}
+
+// decode reads a JSON request body into msg, writing an error response and
+// returning false if it is malformed.
+func decode(w http.ResponseWriter, r *http.Request, msg proto.Message) bool {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, status.New(codes.InvalidArgument, err.Error()))
+		return false
+	}
+	if err := unmarshaler.Unmarshal(body, msg); err != nil {
+		writeError(w, http.StatusBadRequest, status.New(codes.InvalidArgument, "invalid JSON body: "+err.Error()))
+		return false
+	}
+	return true
+}
+
+// decodeQuery sets the named fields of msg from the query string, writing
+// an error response and returning false if a value is malformed. Repeated
+// fields take every occurrence of their parameter.
+func decodeQuery(w http.ResponseWriter, r *http.Request, msg proto.Message, names ...string) bool {
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	values := make(map[string]any)
+	for _, name := range names {
+		got := r.URL.Query()[name]
+		field := fields.ByName(protoreflect.Name(name))
+		switch {
+		case len(got) == 0 || field == nil:
+		case field.IsList():
+			values[name] = got
+		default:
+			values[name] = got[0]
+		}
+	}
+
+	// Protobuf JSON accepts numbers and enum names as strings, so the
+	// values can be decoded as a JSON body would be.
+	body, err := json.Marshal(values)
+	if err == nil {
+		err = unmarshaler.Unmarshal(body, msg)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, status.New(codes.InvalidArgument, "invalid query: "+err.Error()))
+		return false
+	}
+	return true
+}
+
+// respond writes resp as JSON, or the error derived from err.
+func respond(w http.ResponseWriter, resp proto.Message, err error) {
+	if err != nil {
+		st := status.Convert(err)
+		writeError(w, HTTPStatusFromCode(st.Code()), st)
+		return
+	}
+
+	body, err := marshaler.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, status.New(codes.Internal, err.Error()))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// Error is the JSON body returned for every failed request.
+type Error struct {
+	Code    int    `json:"code"`    // numeric gRPC status code
+	Status  string `json:"status"`  // gRPC status name, e.g. NOT_FOUND
+	Message string `json:"message"` // human readable description
+}
+
+// writeError writes st as an Error with the given HTTP status.
+func writeError(w http.ResponseWriter, httpStatus int, st *status.Status) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	json.NewEncoder(w).Encode(Error{
+		Code:    int(st.Code()),
+		Status:  codeName(st.Code()),
+		Message: st.Message(),
+	})
+}
@@ -0,0 +1,197 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	train "ticketing-svc/proto"
+	"ticketing-svc/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGateway serves a fresh ticket service over an in-memory gRPC
+// connection and returns an HTTP server for a Gateway in front of it.
+func newTestGateway(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	train.RegisterTicketServiceServer(s, service.NewServer())
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	srv := httptest.NewServer(New(train.NewTicketServiceClient(conn)))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestGateway_ServeHTTP(t *testing.T) {
+	srv := newTestGateway(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantBody   string // substring expected in the response body
+	}{
+		{
+			name:       "success - purchase ticket",
+			method:     http.MethodPost,
+			path:       "/tickets",
+			body:       `{"from":"London","to":"France","user":{"firstName":"John","lastName":"Doe","email":"john.doe@example.com"},"pricePaid":20}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"seat":"A-0"`,
+		},
+		{
+			name:       "success - get receipt",
+			method:     http.MethodGet,
+			path:       "/tickets/john.doe%40example.com",
+			wantStatus: http.StatusOK,
+			wantBody:   `"email":"john.doe@example.com"`,
+		},
+		{
+			name:       "success - view seats",
+			method:     http.MethodGet,
+			path:       "/sections/A/seats",
+			wantStatus: http.StatusOK,
+			wantBody:   `"firstName":"John"`,
+		},
+		{
+			name:       "success - view seats filtered and paged",
+			method:     http.MethodGet,
+			path:       "/sections/A/seats?states=PAID&states=CHECKED_IN&class=STANDARD&page_size=1",
+			wantStatus: http.StatusOK,
+			wantBody:   `"seats":[{"seat":"A-0"`,
+		},
+		{
+			name:       "fail - malformed query",
+			method:     http.MethodGet,
+			path:       "/sections/A/seats?page_size=many",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"status":"INVALID_ARGUMENT"`,
+		},
+		{
+			name:       "success - modify seat",
+			method:     http.MethodPatch,
+			path:       "/tickets/john.doe@example.com/seat",
+			body:       `{"newSeat":"B-1"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `Seat modified successfully`,
+		},
+		{
+			name:       "success - ticket document",
+			method:     http.MethodGet,
+			path:       "/tickets/john.doe@example.com/document?format=HTML",
+			wantStatus: http.StatusOK,
+			wantBody:   `"contentType":"text/html`,
+		},
+		{
+			name:       "success - boarding pass",
+			method:     http.MethodGet,
+			path:       "/tickets/john.doe@example.com/boarding-pass",
+			wantStatus: http.StatusOK,
+			wantBody:   `"qrPng":`,
+		},
+		{
+			name:       "success - validate a tampered boarding pass",
+			method:     http.MethodPost,
+			path:       "/boarding-passes/validate",
+			body:       `{"code":"not a pass"}`,
+			wantStatus: http.StatusOK,
+			wantBody:   `"result":"TAMPERED"`,
+		},
+		{
+			name:       "success - remove user",
+			method:     http.MethodDelete,
+			path:       "/tickets/john.doe@example.com",
+			wantStatus: http.StatusOK,
+			wantBody:   `User removed successfully`,
+		},
+		{
+			name:       "fail - receipt not found",
+			method:     http.MethodGet,
+			path:       "/tickets/john.doe@example.com",
+			wantStatus: http.StatusNotFound,
+			wantBody:   `"status":"NOT_FOUND"`,
+		},
+		{
+			name:       "fail - purchase without email",
+			method:     http.MethodPost,
+			path:       "/tickets",
+			body:       `{"from":"London"}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"status":"INVALID_ARGUMENT"`,
+		},
+		{
+			name:       "fail - malformed body",
+			method:     http.MethodPost,
+			path:       "/tickets",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `"code":3`,
+		},
+		{
+			name:       "success - OpenAPI document",
+			method:     http.MethodGet,
+			path:       "/openapi.json",
+			wantStatus: http.StatusOK,
+			wantBody:   `"openapi": "3.0.3"`,
+		},
+		{
+			name:       "fail - wrong method",
+			method:     http.MethodPut,
+			path:       "/tickets",
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "fail - unknown route",
+			method:     http.MethodGet,
+			path:       "/trains",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, srv.URL+tt.path, strings.NewReader(tt.body))
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", resp.StatusCode, tt.wantStatus, body)
+			}
+			if !strings.Contains(string(body), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", body, tt.wantBody)
+			}
+			if resp.StatusCode != http.StatusOK {
+				var e Error
+				if err := json.Unmarshal(body, &e); err != nil || e.Status == "" {
+					t.Errorf("error body %s is not an Error: %v", body, err)
+				}
+			}
+		})
+	}
+}
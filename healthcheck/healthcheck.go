@@ -0,0 +1,91 @@
+// Package healthcheck keeps the grpc.health.v1 status of the ticket service
+// in step with the health of the dependencies it relies on.
+package healthcheck
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
+
+// Monitor runs dependency checks and publishes the results on a health
+// server. Each dependency is reported under its own name, and the overall
+// status ("" and the service name) is SERVING only while every check passes.
+type Monitor struct {
+	server  *health.Server
+	service string
+	timeout time.Duration
+
+	mu     sync.Mutex // protects checks
+	checks map[string]Check
+}
+
+// NewMonitor returns a Monitor publishing to server. service is the fully
+// qualified gRPC service name that shares the overall status.
+func NewMonitor(server *health.Server, service string) *Monitor {
+	return &Monitor{
+		server:  server,
+		service: service,
+		timeout: 5 * time.Second,
+		checks:  make(map[string]Check),
+	}
+}
+
+// Add registers a dependency check under name.
+func (m *Monitor) Add(name string, check Check) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checks[name] = check
+}
+
+// CheckNow runs every check once and updates the published statuses.
+func (m *Monitor) CheckNow(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overall := healthpb.HealthCheckResponse_SERVING
+	for name, check := range m.checks {
+		status := healthpb.HealthCheckResponse_SERVING
+
+		checkCtx, cancel := context.WithTimeout(ctx, m.timeout)
+		if err := check(checkCtx); err != nil {
+			log.Printf("health check %q failed: %v", name, err)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			overall = status
+		}
+		cancel()
+
+		m.server.SetServingStatus(name, status)
+	}
+
+	m.server.SetServingStatus("", overall)
+	m.server.SetServingStatus(m.service, overall)
+}
+
+// Run checks the dependencies every interval until ctx is done.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		m.CheckNow(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown marks every service NOT_SERVING and ignores later check results,
+// so load balancers stop routing new traffic while the server drains.
+func (m *Monitor) Shutdown() {
+	m.server.Shutdown()
+}
@@ -0,0 +1,52 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestMonitor_CheckNow(t *testing.T) {
+	var storageErr error
+	hs := health.NewServer()
+	m := NewMonitor(hs, "train.TicketService")
+	m.Add("storage", func(context.Context) error { return storageErr })
+
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("Check(%q) error = %v", service, err)
+		}
+		return resp.Status
+	}
+
+	tests := []struct {
+		name       string
+		storageErr error
+		shutdown   bool
+		want       healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "serving - storage reachable", want: healthpb.HealthCheckResponse_SERVING},
+		{name: "not serving - storage unreachable", storageErr: errors.New("disk gone"), want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "serving - storage recovered", want: healthpb.HealthCheckResponse_SERVING},
+		{name: "not serving - shutting down", shutdown: true, want: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storageErr = tt.storageErr
+			if tt.shutdown {
+				m.Shutdown()
+			}
+			m.CheckNow(context.Background())
+
+			for _, service := range []string{"", "train.TicketService", "storage"} {
+				if got := status(service); got != tt.want {
+					t.Errorf("status(%q) = %v, want %v", service, got, tt.want)
+				}
+			}
+		})
+	}
+}
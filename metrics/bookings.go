@@ -0,0 +1,65 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// bookingCollector turns a service.Stats snapshot into metrics at scrape
+// time, so the figures always match the server's state.
+type bookingCollector struct {
+	source StatsSource
+
+	seatsSold         *prometheus.Desc
+	seatsFree         *prometheus.Desc
+	overbooked        *prometheus.Desc
+	ticketsIssued     *prometheus.Desc
+	removals          *prometheus.Desc
+	seatModifications *prometheus.Desc
+	revenue           *prometheus.Desc
+	refunds           *prometheus.Desc
+	compensation      *prometheus.Desc
+}
+
+func newBookingCollector(source StatsSource) *bookingCollector {
+	name := func(n string) string { return prometheus.BuildFQName(namespace, "", n) }
+	return &bookingCollector{
+		source:            source,
+		seatsSold:         prometheus.NewDesc(name("seats_sold"), "Seats held by current tickets.", []string{"section"}, nil),
+		seatsFree:         prometheus.NewDesc(name("seats_free"), "Seats still available for allocation.", []string{"section"}, nil),
+		overbooked:        prometheus.NewDesc(name("tickets_overbooked"), "Tickets sold beyond the seats and waiting for one.", []string{"section"}, nil),
+		ticketsIssued:     prometheus.NewDesc(name("tickets_issued_total"), "Tickets purchased.", nil, nil),
+		removals:          prometheus.NewDesc(name("ticket_removals_total"), "Passengers removed from the train.", nil, nil),
+		seatModifications: prometheus.NewDesc(name("seat_modifications_total"), "Seat changes made to existing tickets.", nil, nil),
+		revenue:           prometheus.NewDesc(name("revenue_total"), "Sum of the price paid for issued tickets.", nil, nil),
+		refunds:           prometheus.NewDesc(name("refunds_total"), "Fare differences refunded on downgrades and fares of refunded tickets.", nil, nil),
+		compensation:      prometheus.NewDesc(name("compensation_total"), "Compensation paid to overbooked passengers.", nil, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *bookingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.seatsSold
+	ch <- c.seatsFree
+	ch <- c.overbooked
+	ch <- c.ticketsIssued
+	ch <- c.removals
+	ch <- c.seatModifications
+	ch <- c.revenue
+	ch <- c.refunds
+	ch <- c.compensation
+}
+
+// Collect implements prometheus.Collector.
+func (c *bookingCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.source.Stats()
+
+	for section, s := range stats.Sections {
+		ch <- prometheus.MustNewConstMetric(c.seatsSold, prometheus.GaugeValue, float64(s.Sold), section)
+		ch <- prometheus.MustNewConstMetric(c.seatsFree, prometheus.GaugeValue, float64(s.Free), section)
+		ch <- prometheus.MustNewConstMetric(c.overbooked, prometheus.GaugeValue, float64(s.Overbooked), section)
+	}
+	ch <- prometheus.MustNewConstMetric(c.ticketsIssued, prometheus.CounterValue, float64(stats.TicketsIssued))
+	ch <- prometheus.MustNewConstMetric(c.removals, prometheus.CounterValue, float64(stats.Removals))
+	ch <- prometheus.MustNewConstMetric(c.seatModifications, prometheus.CounterValue, float64(stats.SeatModifications))
+	ch <- prometheus.MustNewConstMetric(c.revenue, prometheus.CounterValue, stats.Revenue)
+	ch <- prometheus.MustNewConstMetric(c.refunds, prometheus.CounterValue, stats.Refunds)
+	ch <- prometheus.MustNewConstMetric(c.compensation, prometheus.CounterValue, stats.Compensation)
+}
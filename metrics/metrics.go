@@ -0,0 +1,96 @@
+// Package metrics exposes Prometheus metrics for the ticket service: RPC
+// latency and errors recorded by an interceptor, and booking figures read
+// from the service on every scrape.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"ticketing-svc/service"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "ticketing"
+
+// StatsSource provides the booking summary exported on each scrape.
+type StatsSource interface {
+	Stats() service.Stats
+}
+
+// Metrics owns the registry served on /metrics.
+type Metrics struct {
+	registry    *prometheus.Registry
+	rpcDuration *prometheus.HistogramVec
+	rpcErrors   *prometheus.CounterVec
+}
+
+// New creates a registry with the RPC metrics, the booking metrics read from
+// source, and the standard Go runtime and process collectors.
+func New(source StatsSource) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "rpc_duration_seconds",
+			Help:      "Latency of TicketService RPCs; for streams, how long they stayed open.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		rpcErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "rpc_errors_total",
+			Help:      "TicketService RPCs that returned an error.",
+		}, []string{"method", "code"}),
+	}
+
+	m.registry.MustRegister(
+		m.rpcDuration,
+		m.rpcErrors,
+		newBookingCollector(source),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// UnaryServerInterceptor records the latency and outcome of every unary RPC.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err).String()
+		m.rpcDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+		if err != nil {
+			m.rpcErrors.WithLabelValues(info.FullMethod, code).Inc()
+		}
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the duration and outcome of every
+// streaming RPC.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+
+		code := status.Code(err).String()
+		m.rpcDuration.WithLabelValues(info.FullMethod, code).Observe(time.Since(start).Seconds())
+		if err != nil {
+			m.rpcErrors.WithLabelValues(info.FullMethod, code).Inc()
+		}
+		return err
+	}
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
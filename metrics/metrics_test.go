@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"ticketing-svc/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeStats service.Stats
+
+func (f fakeStats) Stats() service.Stats { return service.Stats(f) }
+
+func TestMetrics_Handler(t *testing.T) {
+	m := New(fakeStats{
+		Sections:          map[string]service.SectionStats{"A": {Sold: 3, Free: 7, Overbooked: 1}},
+		TicketsIssued:     4,
+		Removals:          1,
+		SeatModifications: 2,
+		Revenue:           80,
+		Refunds:           15,
+		Compensation:      25,
+	})
+
+	intercept := m.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/train.TicketService/GetReceipt"}
+	intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "no ticket")
+	})
+	intercept(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, errors.New("plain error")
+	})
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, want := range []string{
+		`ticketing_seats_sold{section="A"} 3`,
+		`ticketing_seats_free{section="A"} 7`,
+		`ticketing_tickets_issued_total 4`,
+		`ticketing_ticket_removals_total 1`,
+		`ticketing_seat_modifications_total 2`,
+		`ticketing_revenue_total 80`,
+		`ticketing_tickets_overbooked{section="A"} 1`,
+		`ticketing_refunds_total 15`,
+		`ticketing_compensation_total 25`,
+		`ticketing_rpc_duration_seconds_count{code="OK",method="/train.TicketService/GetReceipt"} 1`,
+		`ticketing_rpc_errors_total{code="NotFound",method="/train.TicketService/GetReceipt"} 1`,
+		`ticketing_rpc_errors_total{code="Unknown",method="/train.TicketService/GetReceipt"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("/metrics is missing %q", want)
+		}
+	}
+}
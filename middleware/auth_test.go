@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeStream is a grpc.ServerStream carrying only a context.
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context { return s.ctx }
+
+func TestAPIKey(t *testing.T) {
+	keys := []string{"partner-key", "other-key"}
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		wantCode codes.Code
+	}{
+		{name: "fail - missing key", wantCode: codes.Unauthenticated},
+		{name: "fail - metadata without a key", md: metadata.Pairs("x-other", "partner-key"), wantCode: codes.Unauthenticated},
+		{name: "fail - wrong key", md: metadata.Pairs(APIKeyHeader, "guess"), wantCode: codes.Unauthenticated},
+		{name: "fail - key prefix", md: metadata.Pairs(APIKeyHeader, "partner"), wantCode: codes.Unauthenticated},
+		{name: "success - valid key", md: metadata.Pairs(APIKeyHeader, "partner-key")},
+		{name: "success - second valid key", md: metadata.Pairs(APIKeyHeader, "other-key")},
+		{name: "success - valid key among several", md: metadata.Pairs(APIKeyHeader, "guess", APIKeyHeader, "other-key")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			called := false
+			unary := APIKey(keys)
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/train.TicketService/GetReceipt"}, func(context.Context, interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			if status.Code(err) != tt.wantCode || called != (tt.wantCode == codes.OK) {
+				t.Errorf("APIKey() error = %v, handler called %v, want %v", err, called, tt.wantCode)
+			}
+
+			called = false
+			stream := StreamAPIKey(keys)
+			err = stream(nil, &fakeStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/train.TicketService/WatchSeats"}, func(interface{}, grpc.ServerStream) error {
+				called = true
+				return nil
+			})
+			if status.Code(err) != tt.wantCode || called != (tt.wantCode == codes.OK) {
+				t.Errorf("StreamAPIKey() error = %v, handler called %v, want %v", err, called, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServiceAPIKey(t *testing.T) {
+	admin := []string{"admin-key"}
+	tests := []struct {
+		name     string
+		keys     []string
+		method   string
+		md       metadata.MD
+		wantCode codes.Code
+	}{
+		{name: "fail - missing key", keys: admin, method: "/train.Agent/ListOverbooked", wantCode: codes.PermissionDenied},
+		{name: "fail - wrong key", keys: admin, method: "/train.Agent/ListOverbooked", md: metadata.Pairs(APIKeyHeader, "guess"), wantCode: codes.PermissionDenied},
+		{name: "fail - regular key on an admin service", keys: admin, method: "/train.Agent/ListOverbooked", md: metadata.Pairs(APIKeyHeader, "partner-key"), wantCode: codes.PermissionDenied},
+		{name: "fail - no admin keys configured", method: "/train.Agent/ListOverbooked", md: metadata.Pairs(APIKeyHeader, "admin-key"), wantCode: codes.PermissionDenied},
+		{name: "success - admin key", keys: admin, method: "/train.Agent/ListOverbooked", md: metadata.Pairs(APIKeyHeader, "admin-key")},
+		{name: "success - other service not checked", keys: admin, method: "/train.TicketService/GetReceipt"},
+		{name: "success - service name prefix not matched", keys: admin, method: "/train.AgentTools/List"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			unary := ServiceAPIKey("train.Agent", tt.keys)
+			_, err := unary(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("ServiceAPIKey() error = %v, want %v", err, tt.wantCode)
+			}
+
+			stream := StreamServiceAPIKey("train.Agent", tt.keys)
+			err = stream(nil, &fakeStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: tt.method}, func(interface{}, grpc.ServerStream) error {
+				return nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("StreamServiceAPIKey() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+}
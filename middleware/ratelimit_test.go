@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRateLimit(t *testing.T) {
+	// One token every 50ms with a burst of two. Steps run in order against
+	// the same limiter.
+	tests := []struct {
+		name     string
+		wait     time.Duration // before the request
+		wantCode codes.Code
+	}{
+		{name: "success - first request"},
+		{name: "success - within the burst"},
+		{name: "fail - over the limit", wantCode: codes.ResourceExhausted},
+		{name: "fail - still over the limit", wantCode: codes.ResourceExhausted},
+		{name: "success - refilled", wait: 120 * time.Millisecond},
+	}
+
+	t.Run("unary", func(t *testing.T) {
+		unary := RateLimit(rate.NewLimiter(rate.Every(50*time.Millisecond), 2))
+		for _, tt := range tests {
+			time.Sleep(tt.wait)
+			_, err := unary(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/train.TicketService/GetReceipt"}, func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("%s: RateLimit() error = %v, want %v", tt.name, err, tt.wantCode)
+			}
+		}
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		stream := StreamRateLimit(rate.NewLimiter(rate.Every(50*time.Millisecond), 2))
+		for _, tt := range tests {
+			time.Sleep(tt.wait)
+			err := stream(nil, &fakeStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/train.TicketService/WatchSeats"}, func(interface{}, grpc.ServerStream) error {
+				return nil
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("%s: StreamRateLimit() error = %v, want %v", tt.name, err, tt.wantCode)
+			}
+		}
+	})
+}
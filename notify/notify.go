@@ -0,0 +1,61 @@
+// Package notify sends passengers email about their tickets: a receipt on
+// purchase, and messages when their seat changes, when their ticket is
+// cancelled and shortly before departure.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"ticketing-svc/events"
+)
+
+// Message is a rendered notification.
+type Message struct {
+	To      string // email address
+	Subject string
+	Body    string
+}
+
+// Notifier sends messages to passengers.
+type Notifier interface {
+	Notify(ctx context.Context, m Message) error
+}
+
+// DepartureReminder names the template used for departure reminders. The
+// other templates are named after the event type they describe.
+const DepartureReminder = "departure.reminder"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// templates holds one template per message kind, named after its file. The
+// first line of the output is the subject and the rest is the body.
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// Data is what the message templates are executed with.
+type Data struct {
+	events.Event
+	Departure time.Time // set for departure reminders
+}
+
+// Render executes the template called name for d, addressed to d.Email. It
+// reports false if there is no such template.
+func Render(name string, d Data) (Message, bool, error) {
+	tmpl := templates.Lookup(name + ".tmpl")
+	if tmpl == nil {
+		return Message{}, false, nil
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, d); err != nil {
+		return Message{}, true, fmt.Errorf("render %s: %w", name, err)
+	}
+	subject, body, _ := strings.Cut(out.String(), "\n")
+	return Message{To: d.Email, Subject: subject, Body: body}, true, nil
+}
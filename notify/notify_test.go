@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+)
+
+func TestSink_Deliver(t *testing.T) {
+	receipt := &train.Receipt{
+		From:      "London",
+		To:        "France",
+		User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		PricePaid: 20,
+		Seat:      "B-3",
+	}
+	modified := events.FromReceipt(events.SeatModified, receipt)
+	modified.PreviousSeat = "A-0"
+
+	tests := []struct {
+		name        string
+		event       events.Event
+		wantSubject string
+		wantBody    []string
+	}{
+		{
+			name:        "success - receipt",
+			event:       events.FromReceipt(events.TicketPurchased, receipt),
+			wantSubject: "Your ticket from London to France is confirmed",
+			wantBody:    []string{"Hello John,", "Seat:       B-3", "Price paid: 20.00"},
+		},
+		{
+			name:        "success - seat changed",
+			event:       modified,
+			wantSubject: "Your seat has changed to B-3",
+			wantBody:    []string{"Previous seat: A-0", "New seat:      B-3"},
+		},
+		{
+			name:        "success - cancellation",
+			event:       events.FromReceipt(events.TicketCancelled, receipt),
+			wantSubject: "Your ticket from London to France has been cancelled",
+			wantBody:    []string{"(seat B-3)"},
+		},
+		{
+			name:        "success - seat for an overbooked ticket",
+			event:       events.FromReceipt(events.WaitlistPromoted, receipt),
+			wantSubject: "You have a seat on the train from London to France",
+			wantBody:    []string{"Seat:              B-3"},
+		},
+		{
+			name:  "success - no template",
+			event: events.Event{Type: "ticket.inspected", Email: "john.doe@example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := NewSink(NewWriterNotifier(&out)).Deliver(context.Background(), tt.event); err != nil {
+				t.Fatalf("Sink.Deliver() error = %v", err)
+			}
+			if tt.wantSubject == "" {
+				if out.Len() != 0 {
+					t.Errorf("Sink.Deliver() sent %q, want nothing", out.String())
+				}
+				return
+			}
+			if !strings.HasPrefix(out.String(), "To: john.doe@example.com\nSubject: "+tt.wantSubject+"\n") {
+				t.Errorf("Sink.Deliver() sent %q, want subject %q", out.String(), tt.wantSubject)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Sink.Deliver() body %q does not contain %q", out.String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestRemindBeforeDeparture(t *testing.T) {
+	var out strings.Builder
+	departure := time.Now().Add(time.Hour).UTC()
+	tickets := func() []*train.Receipt {
+		return []*train.Receipt{{
+			From: "London",
+			To:   "France",
+			User: &train.User{FirstName: "John", Email: "john.doe@example.com"},
+			Seat: "A-0",
+		}}
+	}
+
+	RemindBeforeDeparture(context.Background(), NewWriterNotifier(&out), departure, time.Hour-10*time.Millisecond, tickets)
+
+	want := "Subject: Reminder: your train to France departs " + departure.Format("Mon 2 Jan 15:04 MST")
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("reminder = %q, want it to contain %q", out.String(), want)
+	}
+}
+
+func TestSMTPNotifier_message(t *testing.T) {
+	n := &SMTPNotifier{From: "tickets@example.com"}
+	msg := string(n.message(Message{
+		To:      "john.doe@example.com",
+		Subject: "Your ticket from London\rBcc: eve@example.com\r\nX-Spam: yes",
+		Body:    "Hello\nJohn",
+	}, time.Date(2026, 11, 2, 9, 30, 0, 0, time.UTC)))
+
+	headers, body, _ := strings.Cut(msg, "\r\n\r\n")
+	want := "From: tickets@example.com\r\n" +
+		"To: john.doe@example.com\r\n" +
+		"Subject: Your ticket from London Bcc: eve@example.com X-Spam: yes\r\n" +
+		"Date: Mon, 02 Nov 2026 09:30:00 +0000\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=utf-8"
+	if headers != want {
+		t.Errorf("message headers = %q, want %q", headers, want)
+	}
+	if body != "Hello\r\nJohn" {
+		t.Errorf("message body = %q, want %q", body, "Hello\r\nJohn")
+	}
+}
+
+func TestSMSNotifier(t *testing.T) {
+	numbers := map[string]string{"john.doe@example.com": "+441234567890"}
+	tests := []struct {
+		name   string
+		number func(string) string
+		to     string
+		want   string
+	}{
+		{name: "success - number looked up", number: func(email string) string { return numbers[email] }, to: "john.doe@example.com", want: "SMS to +441234567890: Seat changed\n"},
+		{name: "success - no number skipped", number: func(email string) string { return numbers[email] }, to: "jane.doe@example.com"},
+		{name: "success - addressed by email without a lookup", to: "jane.doe@example.com", want: "SMS to jane.doe@example.com: Seat changed\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			n := &SMSNotifier{Sender: NewStubSMSSender(&out), Number: tt.number}
+			if err := n.Notify(context.Background(), Message{To: tt.to, Subject: "Seat changed", Body: "New seat: A-1"}); err != nil {
+				t.Fatalf("SMSNotifier.Notify() error = %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("SMSNotifier.Notify() sent %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
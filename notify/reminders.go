@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+)
+
+// RemindBeforeDeparture waits until before ahead of departure, then sends a
+// departure reminder to every passenger returned by tickets. It returns
+// without sending if ctx is done first or the reminder time has passed.
+func RemindBeforeDeparture(ctx context.Context, n Notifier, departure time.Time, before time.Duration, tickets func() []*train.Receipt) {
+	wait := time.Until(departure.Add(-before))
+	if wait < 0 {
+		log.Printf("departure reminders skipped: reminder time already passed")
+		return
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	sent := 0
+	for _, r := range tickets() {
+		msg, _, err := Render(DepartureReminder, Data{
+			Event:     events.FromReceipt("", r),
+			Departure: departure,
+		})
+		if err == nil {
+			err = n.Notify(ctx, msg)
+		}
+		if err != nil {
+			log.Printf("departure reminder to %s failed: %v", r.GetUser().GetEmail(), err)
+			continue
+		}
+		sent++
+	}
+	log.Printf("sent %d departure reminders", sent)
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+
+	"ticketing-svc/events"
+)
+
+// Sink is an events.Sink that notifies the passenger an event is about.
+// Sending happens in the event dispatcher rather than in the RPC handlers,
+// and failed sends are retried by it.
+type Sink struct {
+	n Notifier
+}
+
+// NewSink returns a Sink sending through n.
+func NewSink(n Notifier) *Sink {
+	return &Sink{n: n}
+}
+
+// Name implements events.Sink.
+func (s *Sink) Name() string { return "notifications" }
+
+// Deliver sends the message for e, ignoring event types that have no
+// template and events without an email address.
+func (s *Sink) Deliver(ctx context.Context, e events.Event) error {
+	if e.Email == "" {
+		return nil
+	}
+	msg, ok, err := Render(string(e.Type), Data{Event: e})
+	if !ok || err != nil {
+		return err
+	}
+	return s.n.Notify(ctx, msg)
+}
@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WriterNotifier writes messages to an io.Writer, for local testing.
+type WriterNotifier struct {
+	mu sync.Mutex // serialises writes
+	w  io.Writer
+}
+
+// NewWriterNotifier returns a Notifier writing messages to w, such as
+// os.Stdout or a file.
+func NewWriterNotifier(w io.Writer) *WriterNotifier {
+	return &WriterNotifier{w: w}
+}
+
+// NewFileNotifier returns a Notifier appending messages to the file at path.
+func NewFileNotifier(path string) (*WriterNotifier, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterNotifier(f), nil
+}
+
+// Notify writes m in the form of a mail message followed by a blank line.
+func (n *WriterNotifier) Notify(ctx context.Context, m Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	_, err := fmt.Fprintf(n.w, "To: %s\nSubject: %s\n\n%s\n", m.To, m.Subject, m.Body)
+	return err
+}
+
+// SMTPNotifier sends messages as plain-text email through an SMTP relay.
+type SMTPNotifier struct {
+	Addr     string // host:port of the relay
+	From     string // sender address
+	Username string // enables PLAIN authentication when set
+	Password string
+}
+
+// Notify sends m. The relay must support STARTTLS when a username is set.
+func (n *SMTPNotifier) Notify(ctx context.Context, m Message) error {
+	var auth smtp.Auth
+	if n.Username != "" {
+		host, _, err := net.SplitHostPort(n.Addr)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+
+	return smtp.SendMail(n.Addr, auth, n.From, []string{m.To}, n.message(m, time.Now()))
+}
+
+// headerLine replaces the line breaks in header values, which may hold
+// passenger input, so they cannot start a header of their own.
+var headerLine = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// message returns m as a mail message sent at now.
+func (n *SMTPNotifier) message(m Message, now time.Time) []byte {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", headerLine.Replace(n.From))
+	fmt.Fprintf(&msg, "To: %s\r\n", headerLine.Replace(m.To))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", headerLine.Replace(m.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	return []byte(msg.String())
+}
+
+// SMSSender sends a text message to a phone number. Implementations wrap
+// an SMS gateway.
+type SMSSender interface {
+	SendSMS(ctx context.Context, to, text string) error
+}
+
+// SMSNotifier sends the subject of each message as a text message.
+type SMSNotifier struct {
+	Sender SMSSender
+	// Number returns the phone number of the passenger with the given
+	// email address, or "" to skip them. When nil, messages are addressed
+	// to the email address itself, which only suits StubSMSSender.
+	Number func(email string) string
+}
+
+// Notify sends the subject of m, which fits in a single text message.
+func (n *SMSNotifier) Notify(ctx context.Context, m Message) error {
+	to := m.To
+	if n.Number != nil {
+		to = n.Number(m.To)
+	}
+	if to == "" {
+		return nil
+	}
+	return n.Sender.SendSMS(ctx, to, m.Subject)
+}
+
+// StubSMSSender writes text messages to an io.Writer instead of sending
+// them, until an SMS gateway is wired in.
+type StubSMSSender struct {
+	mu sync.Mutex // serialises writes
+	w  io.Writer
+}
+
+// NewStubSMSSender returns an SMSSender writing messages to w.
+func NewStubSMSSender(w io.Writer) *StubSMSSender {
+	return &StubSMSSender{w: w}
+}
+
+// SendSMS writes text as one line addressed to to.
+func (s *StubSMSSender) SendSMS(ctx context.Context, to, text string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := fmt.Fprintf(s.w, "SMS to %s: %s\n", to, text)
+	return err
+}
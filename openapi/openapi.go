@@ -0,0 +1,335 @@
+// Package openapi generates the OpenAPI 3 document for the HTTP/JSON
+// gateway from ticketing.proto and the gateway's route table, so the
+// published contract always matches what the gateway serves.
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"ticketing-svc/gateway"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Document is the subset of the OpenAPI 3.0 object model used by the spec.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Operation describes one HTTP method on a path.
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter describes a path or query parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes a JSON request body.
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response describes a JSON response.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType wraps the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is an OpenAPI schema object.
+type Schema struct {
+	Ref         string             `json:"$ref,omitempty"`
+	AllOf       []*Schema          `json:"allOf,omitempty"`
+	Type        string             `json:"type,omitempty"`
+	Format      string             `json:"format,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+}
+
+// errorSchema is the gateway's error body, gateway.Error.
+const errorSchema = "Error"
+
+// Generate compiles protoFile, found relative to root, and returns the
+// OpenAPI document for the gateway routes as indented JSON.
+func Generate(root, protoFile string) ([]byte, error) {
+	compiler := protocompile.Compiler{
+		Resolver:       protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: []string{root}}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	files, err := compiler.Compile(context.Background(), protoFile)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: compile %s: %w", protoFile, err)
+	}
+
+	doc, err := build(files[0])
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
+
+// build assembles the document for the TicketService in fd.
+func build(fd protoreflect.FileDescriptor) (*Document, error) {
+	svc := fd.Services().ByName("TicketService")
+	if svc == nil {
+		return nil, fmt.Errorf("openapi: %s does not define TicketService", fd.Path())
+	}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info: Info{
+			Title:       "Train Ticket Service",
+			Description: comment(fd, svc),
+			Version:     "1.0.0",
+		},
+		Paths:      make(map[string]map[string]Operation),
+		Components: Components{Schemas: make(map[string]*Schema)},
+	}
+
+	routed := make(map[string]bool)
+	for _, route := range gateway.Routes {
+		method := svc.Methods().ByName(protoreflect.Name(route.RPC))
+		if method == nil {
+			return nil, fmt.Errorf("openapi: route %s %s calls unknown RPC %s", route.Method, route.Pattern, route.RPC)
+		}
+		routed[route.RPC] = true
+		op, err := operation(fd, route, method)
+		if err != nil {
+			return nil, err
+		}
+		if doc.Paths[route.Pattern] == nil {
+			doc.Paths[route.Pattern] = make(map[string]Operation)
+		}
+		doc.Paths[route.Pattern][strings.ToLower(route.Method)] = op
+	}
+
+	// Every unary RPC must be reachable over HTTP. Streaming RPCs have no
+	// JSON equivalent and are served over gRPC only.
+	var streaming []string
+	methods := svc.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		name := string(method.Name())
+		switch {
+		case method.IsStreamingClient() || method.IsStreamingServer():
+			streaming = append(streaming, name)
+		case !routed[name]:
+			return nil, fmt.Errorf("openapi: RPC %s has no gateway route", name)
+		}
+	}
+	if len(streaming) > 0 {
+		doc.Info.Description += " Streaming RPCs (" + strings.Join(streaming, ", ") + ") are served over gRPC only."
+	}
+
+	messages := fd.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		msg := messages.Get(i)
+		doc.Components.Schemas[string(msg.Name())] = messageSchema(fd, msg)
+	}
+	doc.Components.Schemas[errorSchema] = &Schema{
+		Type:        "object",
+		Description: "Returned with every non-2xx response.",
+		Properties: map[string]*Schema{
+			"code":    {Type: "integer", Format: "int32", Description: "Numeric gRPC status code."},
+			"status":  {Type: "string", Description: "gRPC status name, e.g. NOT_FOUND."},
+			"message": {Type: "string", Description: "Human readable description of the error."},
+		},
+	}
+
+	return doc, nil
+}
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// operation describes route, which calls method.
+func operation(fd protoreflect.FileDescriptor, route gateway.Route, method protoreflect.MethodDescriptor) (Operation, error) {
+	input, output := method.Input(), method.Output()
+	op := Operation{
+		OperationID: route.RPC,
+		Summary:     comment(fd, method),
+		Tags:        []string{"TicketService"},
+		Responses: map[string]Response{
+			"200": {
+				Description: "A successful response.",
+				Content:     jsonContent(ref(output.Name())),
+			},
+			"default": {
+				Description: "An error derived from the gRPC status.",
+				Content:     jsonContent(ref(errorSchema)),
+			},
+		},
+	}
+
+	for _, match := range pathParam.FindAllStringSubmatch(route.Pattern, -1) {
+		field := input.Fields().ByName(protoreflect.Name(match[1]))
+		if field == nil {
+			return Operation{}, fmt.Errorf("openapi: path parameter %s is not a field of %s", match[1], input.Name())
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        match[1],
+			In:          "path",
+			Description: comment(fd, field),
+			Required:    true,
+			Schema:      fieldType(field),
+		})
+	}
+
+	for _, name := range route.Query {
+		field := input.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return Operation{}, fmt.Errorf("openapi: query parameter %s is not a field of %s", name, input.Name())
+		}
+		schema := fieldType(field)
+		if field.IsList() {
+			// Repeated fields take the parameter once per value.
+			schema = &Schema{Type: "array", Items: schema}
+		}
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:        name,
+			In:          "query",
+			Description: comment(fd, field),
+			Schema:      schema,
+		})
+	}
+
+	if route.Body {
+		desc := ""
+		if len(op.Parameters) > 0 {
+			desc = "Fields also present in the path are taken from the path."
+		}
+		op.RequestBody = &RequestBody{
+			Description: desc,
+			Required:    true,
+			Content:     jsonContent(ref(input.Name())),
+		}
+	}
+
+	return op, nil
+}
+
+// messageSchema describes msg using its protobuf JSON field names.
+func messageSchema(fd protoreflect.FileDescriptor, msg protoreflect.MessageDescriptor) *Schema {
+	schema := &Schema{
+		Type:        "object",
+		Description: comment(fd, msg),
+		Properties:  make(map[string]*Schema),
+	}
+
+	fields := msg.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		prop := fieldType(field)
+		if field.IsList() {
+			prop = &Schema{Type: "array", Items: prop}
+		}
+
+		if desc := comment(fd, field); desc != "" {
+			// Siblings of $ref are ignored, so wrap references to attach
+			// the field description.
+			if prop.Ref != "" {
+				prop = &Schema{AllOf: []*Schema{prop}}
+			}
+			prop.Description = desc
+		}
+		schema.Properties[field.JSONName()] = prop
+	}
+	return schema
+}
+
+// fieldType returns the schema of a single value of field, following the
+// protobuf JSON mapping.
+func fieldType(field protoreflect.FieldDescriptor) *Schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &Schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer", Format: "int64"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// 64-bit integers are encoded as strings in protobuf JSON.
+		return &Schema{Type: "string", Format: "int64"}
+	case protoreflect.FloatKind:
+		return &Schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		return &Schema{Type: "number", Format: "double"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", Format: "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		schema := &Schema{Type: "string"}
+		for i := 0; i < values.Len(); i++ {
+			schema.Enum = append(schema.Enum, string(values.Get(i).Name()))
+		}
+		return schema
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if field.Message().FullName() == "google.protobuf.Timestamp" {
+			// Timestamps are RFC 3339 strings in protobuf JSON.
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		return ref(field.Message().Name())
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// ref returns a reference to the named component schema.
+func ref[T ~string](name T) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + string(name)}
+}
+
+// jsonContent returns an application/json body with schema.
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// comment returns the leading comment of d in fd, joined into one line.
+func comment(fd protoreflect.FileDescriptor, d protoreflect.Descriptor) string {
+	loc := fd.SourceLocations().ByDescriptor(d)
+	return strings.Join(strings.Fields(loc.LeadingComments), " ")
+}
@@ -0,0 +1,34 @@
+package openapi
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden OpenAPI document")
+
+// golden is the document embedded and served by the gateway.
+const golden = "../gateway/openapi.json"
+
+func TestGenerate_Golden(t *testing.T) {
+	got, err := Generate("..", "proto/ticketing.proto")
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s is out of date with ticketing.proto or the gateway routes; run `go generate ./gateway` or `go test ./openapi -update`", golden)
+	}
+}
@@ -0,0 +1,771 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v4.25.0
+// source: proto/admin.proto
+
+package train
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// WebhookAdminClient is the client API for WebhookAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WebhookAdminClient interface {
+	// Subscribes a URL to booking events. The response is the only place the
+	// signing secret is returned.
+	RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*Webhook, error)
+	// Lists the webhook subscriptions without their secrets.
+	ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	// Removes a webhook subscription and drops its pending deliveries.
+	DeleteWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Lists deliveries that failed on every attempt.
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	// Queues a dead-lettered delivery for a fresh round of attempts.
+	ReplayWebhook(ctx context.Context, in *ReplayWebhookRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+}
+
+type webhookAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWebhookAdminClient(cc grpc.ClientConnInterface) WebhookAdminClient {
+	return &webhookAdminClient{cc}
+}
+
+func (c *webhookAdminClient) RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*Webhook, error) {
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, "/train.WebhookAdmin/RegisterWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookAdminClient) ListWebhooks(ctx context.Context, in *ListWebhooksRequest, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, "/train.WebhookAdmin/ListWebhooks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookAdminClient) DeleteWebhook(ctx context.Context, in *WebhookRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/train.WebhookAdmin/DeleteWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookAdminClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, "/train.WebhookAdmin/ListDeadLetters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *webhookAdminClient) ReplayWebhook(ctx context.Context, in *ReplayWebhookRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/train.WebhookAdmin/ReplayWebhook", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WebhookAdminServer is the server API for WebhookAdmin service.
+// All implementations must embed UnimplementedWebhookAdminServer
+// for forward compatibility
+type WebhookAdminServer interface {
+	// Subscribes a URL to booking events. The response is the only place the
+	// signing secret is returned.
+	RegisterWebhook(context.Context, *RegisterWebhookRequest) (*Webhook, error)
+	// Lists the webhook subscriptions without their secrets.
+	ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error)
+	// Removes a webhook subscription and drops its pending deliveries.
+	DeleteWebhook(context.Context, *WebhookRequest) (*StatusResponse, error)
+	// Lists deliveries that failed on every attempt.
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	// Queues a dead-lettered delivery for a fresh round of attempts.
+	ReplayWebhook(context.Context, *ReplayWebhookRequest) (*StatusResponse, error)
+	mustEmbedUnimplementedWebhookAdminServer()
+}
+
+// UnimplementedWebhookAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedWebhookAdminServer struct {
+}
+
+func (UnimplementedWebhookAdminServer) RegisterWebhook(context.Context, *RegisterWebhookRequest) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWebhook not implemented")
+}
+func (UnimplementedWebhookAdminServer) ListWebhooks(context.Context, *ListWebhooksRequest) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedWebhookAdminServer) DeleteWebhook(context.Context, *WebhookRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedWebhookAdminServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedWebhookAdminServer) ReplayWebhook(context.Context, *ReplayWebhookRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhook not implemented")
+}
+func (UnimplementedWebhookAdminServer) mustEmbedUnimplementedWebhookAdminServer() {}
+
+// UnsafeWebhookAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WebhookAdminServer will
+// result in compilation errors.
+type UnsafeWebhookAdminServer interface {
+	mustEmbedUnimplementedWebhookAdminServer()
+}
+
+func RegisterWebhookAdminServer(s grpc.ServiceRegistrar, srv WebhookAdminServer) {
+	s.RegisterService(&WebhookAdmin_ServiceDesc, srv)
+}
+
+func _WebhookAdmin_RegisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServer).RegisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.WebhookAdmin/RegisterWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServer).RegisterWebhook(ctx, req.(*RegisterWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookAdmin_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.WebhookAdmin/ListWebhooks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServer).ListWebhooks(ctx, req.(*ListWebhooksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookAdmin_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.WebhookAdmin/DeleteWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServer).DeleteWebhook(ctx, req.(*WebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookAdmin_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.WebhookAdmin/ListDeadLetters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WebhookAdmin_ReplayWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WebhookAdminServer).ReplayWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.WebhookAdmin/ReplayWebhook",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WebhookAdminServer).ReplayWebhook(ctx, req.(*ReplayWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WebhookAdmin_ServiceDesc is the grpc.ServiceDesc for WebhookAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WebhookAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "train.WebhookAdmin",
+	HandlerType: (*WebhookAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterWebhook",
+			Handler:    _WebhookAdmin_RegisterWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _WebhookAdmin_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _WebhookAdmin_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _WebhookAdmin_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "ReplayWebhook",
+			Handler:    _WebhookAdmin_ReplayWebhook_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/admin.proto",
+}
+
+// AgentClient is the client API for Agent service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AgentClient interface {
+	// Lists the passengers needing assistance, where they board and alight.
+	ListAssistance(ctx context.Context, in *AssistanceRequest, opts ...grpc.CallOption) (*AssistanceList, error)
+	// Lists the tickets sold beyond capacity that have no seat, oldest first.
+	ListOverbooked(ctx context.Context, in *OverbookedRequest, opts ...grpc.CallOption) (*OverbookedList, error)
+	// Settles an overbooked ticket at check-in.
+	ResolveOverbooking(ctx context.Context, in *ResolveOverbookingRequest, opts ...grpc.CallOption) (*ResolveOverbookingResponse, error)
+	// Streams the passenger manifest as a document in chunks, so large trains
+	// are not held in one message.
+	ExportManifest(ctx context.Context, in *ManifestRequest, opts ...grpc.CallOption) (Agent_ExportManifestClient, error)
+	// Books tickets for the passengers in a CSV document streamed in chunks,
+	// reporting the outcome of each row. Valid rows are booked even when
+	// others fail.
+	ImportTickets(ctx context.Context, opts ...grpc.CallOption) (Agent_ImportTicketsClient, error)
+	// Refunds the fare of a cancelled or no-show ticket.
+	RefundTicket(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
+}
+
+type agentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAgentClient(cc grpc.ClientConnInterface) AgentClient {
+	return &agentClient{cc}
+}
+
+func (c *agentClient) ListAssistance(ctx context.Context, in *AssistanceRequest, opts ...grpc.CallOption) (*AssistanceList, error) {
+	out := new(AssistanceList)
+	err := c.cc.Invoke(ctx, "/train.Agent/ListAssistance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ListOverbooked(ctx context.Context, in *OverbookedRequest, opts ...grpc.CallOption) (*OverbookedList, error) {
+	out := new(OverbookedList)
+	err := c.cc.Invoke(ctx, "/train.Agent/ListOverbooked", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ResolveOverbooking(ctx context.Context, in *ResolveOverbookingRequest, opts ...grpc.CallOption) (*ResolveOverbookingResponse, error) {
+	out := new(ResolveOverbookingResponse)
+	err := c.cc.Invoke(ctx, "/train.Agent/ResolveOverbooking", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) ExportManifest(ctx context.Context, in *ManifestRequest, opts ...grpc.CallOption) (Agent_ExportManifestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[0], "/train.Agent/ExportManifest", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentExportManifestClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_ExportManifestClient interface {
+	Recv() (*ManifestChunk, error)
+	grpc.ClientStream
+}
+
+type agentExportManifestClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentExportManifestClient) Recv() (*ManifestChunk, error) {
+	m := new(ManifestChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) ImportTickets(ctx context.Context, opts ...grpc.CallOption) (Agent_ImportTicketsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Agent_ServiceDesc.Streams[1], "/train.Agent/ImportTickets", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentImportTicketsClient{stream}
+	return x, nil
+}
+
+type Agent_ImportTicketsClient interface {
+	Send(*ImportChunk) error
+	CloseAndRecv() (*ImportReport, error)
+	grpc.ClientStream
+}
+
+type agentImportTicketsClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentImportTicketsClient) Send(m *ImportChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentImportTicketsClient) CloseAndRecv() (*ImportReport, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportReport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) RefundTicket(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, "/train.Agent/RefundTicket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AgentServer is the server API for Agent service.
+// All implementations must embed UnimplementedAgentServer
+// for forward compatibility
+type AgentServer interface {
+	// Lists the passengers needing assistance, where they board and alight.
+	ListAssistance(context.Context, *AssistanceRequest) (*AssistanceList, error)
+	// Lists the tickets sold beyond capacity that have no seat, oldest first.
+	ListOverbooked(context.Context, *OverbookedRequest) (*OverbookedList, error)
+	// Settles an overbooked ticket at check-in.
+	ResolveOverbooking(context.Context, *ResolveOverbookingRequest) (*ResolveOverbookingResponse, error)
+	// Streams the passenger manifest as a document in chunks, so large trains
+	// are not held in one message.
+	ExportManifest(*ManifestRequest, Agent_ExportManifestServer) error
+	// Books tickets for the passengers in a CSV document streamed in chunks,
+	// reporting the outcome of each row. Valid rows are booked even when
+	// others fail.
+	ImportTickets(Agent_ImportTicketsServer) error
+	// Refunds the fare of a cancelled or no-show ticket.
+	RefundTicket(context.Context, *RefundRequest) (*RefundResponse, error)
+	mustEmbedUnimplementedAgentServer()
+}
+
+// UnimplementedAgentServer must be embedded to have forward compatible implementations.
+type UnimplementedAgentServer struct {
+}
+
+func (UnimplementedAgentServer) ListAssistance(context.Context, *AssistanceRequest) (*AssistanceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssistance not implemented")
+}
+func (UnimplementedAgentServer) ListOverbooked(context.Context, *OverbookedRequest) (*OverbookedList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOverbooked not implemented")
+}
+func (UnimplementedAgentServer) ResolveOverbooking(context.Context, *ResolveOverbookingRequest) (*ResolveOverbookingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveOverbooking not implemented")
+}
+func (UnimplementedAgentServer) ExportManifest(*ManifestRequest, Agent_ExportManifestServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportManifest not implemented")
+}
+func (UnimplementedAgentServer) ImportTickets(Agent_ImportTicketsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTickets not implemented")
+}
+func (UnimplementedAgentServer) RefundTicket(context.Context, *RefundRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefundTicket not implemented")
+}
+func (UnimplementedAgentServer) mustEmbedUnimplementedAgentServer() {}
+
+// UnsafeAgentServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AgentServer will
+// result in compilation errors.
+type UnsafeAgentServer interface {
+	mustEmbedUnimplementedAgentServer()
+}
+
+func RegisterAgentServer(s grpc.ServiceRegistrar, srv AgentServer) {
+	s.RegisterService(&Agent_ServiceDesc, srv)
+}
+
+func _Agent_ListAssistance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssistanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListAssistance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.Agent/ListAssistance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListAssistance(ctx, req.(*AssistanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ListOverbooked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverbookedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ListOverbooked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.Agent/ListOverbooked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ListOverbooked(ctx, req.(*OverbookedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ResolveOverbooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveOverbookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ResolveOverbooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.Agent/ResolveOverbooking",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ResolveOverbooking(ctx, req.(*ResolveOverbookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_ExportManifest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ManifestRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).ExportManifest(m, &agentExportManifestServer{stream})
+}
+
+type Agent_ExportManifestServer interface {
+	Send(*ManifestChunk) error
+	grpc.ServerStream
+}
+
+type agentExportManifestServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentExportManifestServer) Send(m *ManifestChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_ImportTickets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).ImportTickets(&agentImportTicketsServer{stream})
+}
+
+type Agent_ImportTicketsServer interface {
+	SendAndClose(*ImportReport) error
+	Recv() (*ImportChunk, error)
+	grpc.ServerStream
+}
+
+type agentImportTicketsServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentImportTicketsServer) SendAndClose(m *ImportReport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentImportTicketsServer) Recv() (*ImportChunk, error) {
+	m := new(ImportChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_RefundTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).RefundTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.Agent/RefundTicket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).RefundTicket(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Agent_ServiceDesc is the grpc.ServiceDesc for Agent service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Agent_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "train.Agent",
+	HandlerType: (*AgentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAssistance",
+			Handler:    _Agent_ListAssistance_Handler,
+		},
+		{
+			MethodName: "ListOverbooked",
+			Handler:    _Agent_ListOverbooked_Handler,
+		},
+		{
+			MethodName: "ResolveOverbooking",
+			Handler:    _Agent_ResolveOverbooking_Handler,
+		},
+		{
+			MethodName: "RefundTicket",
+			Handler:    _Agent_RefundTicket_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportManifest",
+			Handler:       _Agent_ExportManifest_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportTickets",
+			Handler:       _Agent_ImportTickets_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/admin.proto",
+}
+
+// ReportsClient is the client API for Reports service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReportsClient interface {
+	// Returns how full each section, and the journey as a whole, is.
+	GetOccupancyReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*OccupancyReport, error)
+	// Returns revenue, cancellations, seat changes and ticket sales over time.
+	GetSalesReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*SalesReport, error)
+	// Renders a report as CSV.
+	ExportReport(ctx context.Context, in *ExportReportRequest, opts ...grpc.CallOption) (*ReportDocument, error)
+}
+
+type reportsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReportsClient(cc grpc.ClientConnInterface) ReportsClient {
+	return &reportsClient{cc}
+}
+
+func (c *reportsClient) GetOccupancyReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*OccupancyReport, error) {
+	out := new(OccupancyReport)
+	err := c.cc.Invoke(ctx, "/train.Reports/GetOccupancyReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportsClient) GetSalesReport(ctx context.Context, in *ReportRequest, opts ...grpc.CallOption) (*SalesReport, error) {
+	out := new(SalesReport)
+	err := c.cc.Invoke(ctx, "/train.Reports/GetSalesReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *reportsClient) ExportReport(ctx context.Context, in *ExportReportRequest, opts ...grpc.CallOption) (*ReportDocument, error) {
+	out := new(ReportDocument)
+	err := c.cc.Invoke(ctx, "/train.Reports/ExportReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ReportsServer is the server API for Reports service.
+// All implementations must embed UnimplementedReportsServer
+// for forward compatibility
+type ReportsServer interface {
+	// Returns how full each section, and the journey as a whole, is.
+	GetOccupancyReport(context.Context, *ReportRequest) (*OccupancyReport, error)
+	// Returns revenue, cancellations, seat changes and ticket sales over time.
+	GetSalesReport(context.Context, *ReportRequest) (*SalesReport, error)
+	// Renders a report as CSV.
+	ExportReport(context.Context, *ExportReportRequest) (*ReportDocument, error)
+	mustEmbedUnimplementedReportsServer()
+}
+
+// UnimplementedReportsServer must be embedded to have forward compatible implementations.
+type UnimplementedReportsServer struct {
+}
+
+func (UnimplementedReportsServer) GetOccupancyReport(context.Context, *ReportRequest) (*OccupancyReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOccupancyReport not implemented")
+}
+func (UnimplementedReportsServer) GetSalesReport(context.Context, *ReportRequest) (*SalesReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSalesReport not implemented")
+}
+func (UnimplementedReportsServer) ExportReport(context.Context, *ExportReportRequest) (*ReportDocument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportReport not implemented")
+}
+func (UnimplementedReportsServer) mustEmbedUnimplementedReportsServer() {}
+
+// UnsafeReportsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReportsServer will
+// result in compilation errors.
+type UnsafeReportsServer interface {
+	mustEmbedUnimplementedReportsServer()
+}
+
+func RegisterReportsServer(s grpc.ServiceRegistrar, srv ReportsServer) {
+	s.RegisterService(&Reports_ServiceDesc, srv)
+}
+
+func _Reports_GetOccupancyReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportsServer).GetOccupancyReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.Reports/GetOccupancyReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportsServer).GetOccupancyReport(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reports_GetSalesReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportsServer).GetSalesReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.Reports/GetSalesReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportsServer).GetSalesReport(ctx, req.(*ReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reports_ExportReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReportsServer).ExportReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.Reports/ExportReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReportsServer).ExportReport(ctx, req.(*ExportReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Reports_ServiceDesc is the grpc.ServiceDesc for Reports service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Reports_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "train.Reports",
+	HandlerType: (*ReportsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetOccupancyReport",
+			Handler:    _Reports_GetOccupancyReport_Handler,
+		},
+		{
+			MethodName: "GetSalesReport",
+			Handler:    _Reports_GetSalesReport_Handler,
+		},
+		{
+			MethodName: "ExportReport",
+			Handler:    _Reports_ExportReport_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/admin.proto",
+}
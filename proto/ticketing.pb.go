@@ -9,6 +9,7 @@ package train
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,35 +21,1445 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Classes of travel, from lowest to highest.
+type SeatClass int32
+
+const (
+	SeatClass_SEAT_CLASS_UNSPECIFIED SeatClass = 0
+	SeatClass_STANDARD               SeatClass = 1
+	SeatClass_BUSINESS               SeatClass = 2
+	SeatClass_FIRST                  SeatClass = 3
+)
+
+// Enum value maps for SeatClass.
+var (
+	SeatClass_name = map[int32]string{
+		0: "SEAT_CLASS_UNSPECIFIED",
+		1: "STANDARD",
+		2: "BUSINESS",
+		3: "FIRST",
+	}
+	SeatClass_value = map[string]int32{
+		"SEAT_CLASS_UNSPECIFIED": 0,
+		"STANDARD":               1,
+		"BUSINESS":               2,
+		"FIRST":                  3,
+	}
+)
+
+func (x SeatClass) Enum() *SeatClass {
+	p := new(SeatClass)
+	*p = x
+	return p
+}
+
+func (x SeatClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketing_proto_enumTypes[0].Descriptor()
+}
+
+func (SeatClass) Type() protoreflect.EnumType {
+	return &file_proto_ticketing_proto_enumTypes[0]
+}
+
+func (x SeatClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatClass.Descriptor instead.
+func (SeatClass) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{0}
+}
+
+// The lifecycle of a ticket.
+type TicketState int32
+
+const (
+	TicketState_TICKET_STATE_UNSPECIFIED TicketState = 0
+	// A seat is held but not yet paid for.
+	TicketState_RESERVED TicketState = 1
+	// The ticket is paid for.
+	TicketState_PAID TicketState = 2
+	// The passenger has checked in for the journey.
+	TicketState_CHECKED_IN TicketState = 3
+	// The passenger is on the train.
+	TicketState_BOARDED TicketState = 4
+	// The train left without the passenger.
+	TicketState_NO_SHOW TicketState = 5
+	// The ticket was cancelled and its seat released.
+	TicketState_CANCELLED TicketState = 6
+	// The price paid was returned to the passenger.
+	TicketState_REFUNDED TicketState = 7
+)
+
+// Enum value maps for TicketState.
+var (
+	TicketState_name = map[int32]string{
+		0: "TICKET_STATE_UNSPECIFIED",
+		1: "RESERVED",
+		2: "PAID",
+		3: "CHECKED_IN",
+		4: "BOARDED",
+		5: "NO_SHOW",
+		6: "CANCELLED",
+		7: "REFUNDED",
+	}
+	TicketState_value = map[string]int32{
+		"TICKET_STATE_UNSPECIFIED": 0,
+		"RESERVED":                 1,
+		"PAID":                     2,
+		"CHECKED_IN":               3,
+		"BOARDED":                  4,
+		"NO_SHOW":                  5,
+		"CANCELLED":                6,
+		"REFUNDED":                 7,
+	}
+)
+
+func (x TicketState) Enum() *TicketState {
+	p := new(TicketState)
+	*p = x
+	return p
+}
+
+func (x TicketState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TicketState) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketing_proto_enumTypes[1].Descriptor()
+}
+
+func (TicketState) Type() protoreflect.EnumType {
+	return &file_proto_ticketing_proto_enumTypes[1]
+}
+
+func (x TicketState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TicketState.Descriptor instead.
+func (TicketState) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{1}
+}
+
+// Assistance a passenger may need.
+type AccessibilityNeed int32
+
+const (
+	AccessibilityNeed_ACCESSIBILITY_NEED_UNSPECIFIED AccessibilityNeed = 0
+	// The passenger travels in a wheelchair and needs a wheelchair space.
+	AccessibilityNeed_WHEELCHAIR_SPACE AccessibilityNeed = 1
+	// The passenger accompanies a wheelchair user and needs the seat beside
+	// the space.
+	AccessibilityNeed_COMPANION_SEAT AccessibilityNeed = 2
+	// The passenger needs staff help, e.g. a ramp, to board and alight.
+	AccessibilityNeed_BOARDING_ASSISTANCE AccessibilityNeed = 3
+)
+
+// Enum value maps for AccessibilityNeed.
+var (
+	AccessibilityNeed_name = map[int32]string{
+		0: "ACCESSIBILITY_NEED_UNSPECIFIED",
+		1: "WHEELCHAIR_SPACE",
+		2: "COMPANION_SEAT",
+		3: "BOARDING_ASSISTANCE",
+	}
+	AccessibilityNeed_value = map[string]int32{
+		"ACCESSIBILITY_NEED_UNSPECIFIED": 0,
+		"WHEELCHAIR_SPACE":               1,
+		"COMPANION_SEAT":                 2,
+		"BOARDING_ASSISTANCE":            3,
+	}
+)
+
+func (x AccessibilityNeed) Enum() *AccessibilityNeed {
+	p := new(AccessibilityNeed)
+	*p = x
+	return p
+}
+
+func (x AccessibilityNeed) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessibilityNeed) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketing_proto_enumTypes[2].Descriptor()
+}
+
+func (AccessibilityNeed) Type() protoreflect.EnumType {
+	return &file_proto_ticketing_proto_enumTypes[2]
+}
+
+func (x AccessibilityNeed) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessibilityNeed.Descriptor instead.
+func (AccessibilityNeed) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{2}
+}
+
+// Whether a seat can be booked.
+type SeatStatus int32
+
+const (
+	SeatStatus_SEAT_STATUS_UNSPECIFIED SeatStatus = 0
+	// The seat can be booked.
+	SeatStatus_FREE SeatStatus = 1
+	// The seat is reserved by a ticket that has not been paid for.
+	SeatStatus_HELD SeatStatus = 2
+	// The seat belongs to a paid ticket.
+	SeatStatus_SOLD SeatStatus = 3
+	// The operator has taken the seat out of sale.
+	SeatStatus_BLOCKED SeatStatus = 4
+)
+
+// Enum value maps for SeatStatus.
+var (
+	SeatStatus_name = map[int32]string{
+		0: "SEAT_STATUS_UNSPECIFIED",
+		1: "FREE",
+		2: "HELD",
+		3: "SOLD",
+		4: "BLOCKED",
+	}
+	SeatStatus_value = map[string]int32{
+		"SEAT_STATUS_UNSPECIFIED": 0,
+		"FREE":                    1,
+		"HELD":                    2,
+		"SOLD":                    3,
+		"BLOCKED":                 4,
+	}
+)
+
+func (x SeatStatus) Enum() *SeatStatus {
+	p := new(SeatStatus)
+	*p = x
+	return p
+}
+
+func (x SeatStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketing_proto_enumTypes[3].Descriptor()
+}
+
+func (SeatStatus) Type() protoreflect.EnumType {
+	return &file_proto_ticketing_proto_enumTypes[3]
+}
+
+func (x SeatStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatStatus.Descriptor instead.
+func (SeatStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{3}
+}
+
+type SeatEvent_Kind int32
+
+const (
+	SeatEvent_KIND_UNSPECIFIED SeatEvent_Kind = 0
+	// The full occupancy of the section; replaces any earlier state.
+	SeatEvent_SNAPSHOT SeatEvent_Kind = 1
+	// A passenger took a seat.
+	SeatEvent_SEAT_TAKEN SeatEvent_Kind = 2
+	// A passenger left a seat.
+	SeatEvent_SEAT_FREED SeatEvent_Kind = 3
+)
+
+// Enum value maps for SeatEvent_Kind.
+var (
+	SeatEvent_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "SNAPSHOT",
+		2: "SEAT_TAKEN",
+		3: "SEAT_FREED",
+	}
+	SeatEvent_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"SNAPSHOT":         1,
+		"SEAT_TAKEN":       2,
+		"SEAT_FREED":       3,
+	}
+)
+
+func (x SeatEvent_Kind) Enum() *SeatEvent_Kind {
+	p := new(SeatEvent_Kind)
+	*p = x
+	return p
+}
+
+func (x SeatEvent_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SeatEvent_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketing_proto_enumTypes[4].Descriptor()
+}
+
+func (SeatEvent_Kind) Type() protoreflect.EnumType {
+	return &file_proto_ticketing_proto_enumTypes[4]
+}
+
+func (x SeatEvent_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SeatEvent_Kind.Descriptor instead.
+func (SeatEvent_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{14, 0}
+}
+
+type TicketDocumentRequest_Format int32
+
+const (
+	TicketDocumentRequest_FORMAT_UNSPECIFIED TicketDocumentRequest_Format = 0
+	TicketDocumentRequest_PDF                TicketDocumentRequest_Format = 1
+	TicketDocumentRequest_HTML               TicketDocumentRequest_Format = 2
+)
+
+// Enum value maps for TicketDocumentRequest_Format.
+var (
+	TicketDocumentRequest_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "PDF",
+		2: "HTML",
+	}
+	TicketDocumentRequest_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"PDF":                1,
+		"HTML":               2,
+	}
+)
+
+func (x TicketDocumentRequest_Format) Enum() *TicketDocumentRequest_Format {
+	p := new(TicketDocumentRequest_Format)
+	*p = x
+	return p
+}
+
+func (x TicketDocumentRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TicketDocumentRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketing_proto_enumTypes[5].Descriptor()
+}
+
+func (TicketDocumentRequest_Format) Type() protoreflect.EnumType {
+	return &file_proto_ticketing_proto_enumTypes[5]
+}
+
+func (x TicketDocumentRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TicketDocumentRequest_Format.Descriptor instead.
+func (TicketDocumentRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{15, 0}
+}
+
+type ValidateBoardingPassResponse_Result int32
+
+const (
+	ValidateBoardingPassResponse_RESULT_UNSPECIFIED ValidateBoardingPassResponse_Result = 0
+	// The pass is genuine and the passenger may board.
+	ValidateBoardingPassResponse_VALID ValidateBoardingPassResponse_Result = 1
+	// The pass is malformed or its signature does not match its contents.
+	ValidateBoardingPassResponse_TAMPERED ValidateBoardingPassResponse_Result = 2
+	// The booking has been cancelled.
+	ValidateBoardingPassResponse_CANCELLED ValidateBoardingPassResponse_Result = 3
+	// The pass was scanned before.
+	ValidateBoardingPassResponse_ALREADY_SCANNED ValidateBoardingPassResponse_Result = 4
+	// The seat changed after the pass was issued; a new pass is needed.
+	ValidateBoardingPassResponse_SUPERSEDED ValidateBoardingPassResponse_Result = 5
+	// The ticket has boarded or was marked a no-show; its state is in
+	// ticket.
+	ValidateBoardingPassResponse_NOT_BOARDABLE ValidateBoardingPassResponse_Result = 6
+)
+
+// Enum value maps for ValidateBoardingPassResponse_Result.
+var (
+	ValidateBoardingPassResponse_Result_name = map[int32]string{
+		0: "RESULT_UNSPECIFIED",
+		1: "VALID",
+		2: "TAMPERED",
+		3: "CANCELLED",
+		4: "ALREADY_SCANNED",
+		5: "SUPERSEDED",
+		6: "NOT_BOARDABLE",
+	}
+	ValidateBoardingPassResponse_Result_value = map[string]int32{
+		"RESULT_UNSPECIFIED": 0,
+		"VALID":              1,
+		"TAMPERED":           2,
+		"CANCELLED":          3,
+		"ALREADY_SCANNED":    4,
+		"SUPERSEDED":         5,
+		"NOT_BOARDABLE":      6,
+	}
+)
+
+func (x ValidateBoardingPassResponse_Result) Enum() *ValidateBoardingPassResponse_Result {
+	p := new(ValidateBoardingPassResponse_Result)
+	*p = x
+	return p
+}
+
+func (x ValidateBoardingPassResponse_Result) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ValidateBoardingPassResponse_Result) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_ticketing_proto_enumTypes[6].Descriptor()
+}
+
+func (ValidateBoardingPassResponse_Result) Type() protoreflect.EnumType {
+	return &file_proto_ticketing_proto_enumTypes[6]
+}
+
+func (x ValidateBoardingPassResponse_Result) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ValidateBoardingPassResponse_Result.Descriptor instead.
+func (ValidateBoardingPassResponse_Result) EnumDescriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{19, 0}
+}
+
 // The request message containing the user details.
 type PurchaseRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	From      string  `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To        string  `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
-	User      *User   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	PricePaid float64 `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`
+	// Departure station.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Arrival station.
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// The passenger travelling on the ticket.
+	User *User `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// Price paid for the ticket.
+	PricePaid float64 `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`
+	// Seat features the passenger would like; the best available match is
+	// allocated.
+	Preferences *SeatPreferences `protobuf:"bytes,5,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	// A specific seat, e.g. "A-3". The purchase fails with ALREADY_EXISTS if
+	// it is not free; preferences are ignored when it is set.
+	RequestedSeat string `protobuf:"bytes,6,opt,name=requested_seat,json=requestedSeat,proto3" json:"requested_seat,omitempty"`
+	// Identifies passengers travelling together, so allocation strategies
+	// that cluster groups can seat them side by side.
+	Group string `protobuf:"bytes,7,opt,name=group,proto3" json:"group,omitempty"`
+	// Class to travel in; standard if unset. A requested seat decides the
+	// class when this is unset. When the class has a fare, it is charged
+	// instead of price_paid.
+	Class SeatClass `protobuf:"varint,8,opt,name=class,proto3,enum=train.SeatClass" json:"class,omitempty"`
+}
+
+func (x *PurchaseRequest) Reset() {
+	*x = PurchaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PurchaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurchaseRequest) ProtoMessage() {}
+
+func (x *PurchaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurchaseRequest.ProtoReflect.Descriptor instead.
+func (*PurchaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PurchaseRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *PurchaseRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *PurchaseRequest) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *PurchaseRequest) GetPricePaid() float64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *PurchaseRequest) GetPreferences() *SeatPreferences {
+	if x != nil {
+		return x.Preferences
+	}
+	return nil
+}
+
+func (x *PurchaseRequest) GetRequestedSeat() string {
+	if x != nil {
+		return x.RequestedSeat
+	}
+	return ""
+}
+
+func (x *PurchaseRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *PurchaseRequest) GetClass() SeatClass {
+	if x != nil {
+		return x.Class
+	}
+	return SeatClass_SEAT_CLASS_UNSPECIFIED
+}
+
+// Seat features a passenger can ask for.
+type SeatPreferences struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// A seat next to the window.
+	Window bool `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	// A seat next to the aisle.
+	Aisle bool `protobuf:"varint,2,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	// A seat facing the direction of travel.
+	ForwardFacing bool `protobuf:"varint,3,opt,name=forward_facing,json=forwardFacing,proto3" json:"forward_facing,omitempty"`
+	// A seat in a quiet coach.
+	QuietCoach bool `protobuf:"varint,4,opt,name=quiet_coach,json=quietCoach,proto3" json:"quiet_coach,omitempty"`
+}
+
+func (x *SeatPreferences) Reset() {
+	*x = SeatPreferences{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeatPreferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatPreferences) ProtoMessage() {}
+
+func (x *SeatPreferences) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatPreferences.ProtoReflect.Descriptor instead.
+func (*SeatPreferences) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SeatPreferences) GetWindow() bool {
+	if x != nil {
+		return x.Window
+	}
+	return false
+}
+
+func (x *SeatPreferences) GetAisle() bool {
+	if x != nil {
+		return x.Aisle
+	}
+	return false
+}
+
+func (x *SeatPreferences) GetForwardFacing() bool {
+	if x != nil {
+		return x.ForwardFacing
+	}
+	return false
+}
+
+func (x *SeatPreferences) GetQuietCoach() bool {
+	if x != nil {
+		return x.QuietCoach
+	}
+	return false
+}
+
+// The response message containing the receipt details.
+type Receipt struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Departure station.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Arrival station.
+	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// The passenger travelling on the ticket.
+	User *User `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// Price paid for the ticket.
+	PricePaid float64 `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`
+	// Assigned seat, written as section and number, e.g. "A-3".
+	Seat string `protobuf:"bytes,5,opt,name=seat,proto3" json:"seat,omitempty"`
+	// Short code identifying the booking, printed on the ticket.
+	BookingReference string `protobuf:"bytes,6,opt,name=booking_reference,json=bookingReference,proto3" json:"booking_reference,omitempty"`
+	// Where the ticket is in its lifecycle.
+	State TicketState `protobuf:"varint,7,opt,name=state,proto3,enum=train.TicketState" json:"state,omitempty"`
+	// Every state the ticket has been in, oldest first.
+	History []*StateChange `protobuf:"bytes,8,rep,name=history,proto3" json:"history,omitempty"`
+	// The requested seat preferences that the allocated seat satisfies. Unset
+	// if none were requested.
+	HonouredPreferences *SeatPreferences `protobuf:"bytes,9,opt,name=honoured_preferences,json=honouredPreferences,proto3" json:"honoured_preferences,omitempty"`
+	// The group the passenger travels with, if any.
+	Group string `protobuf:"bytes,10,opt,name=group,proto3" json:"group,omitempty"`
+	// Class of the seat.
+	Class SeatClass `protobuf:"varint,11,opt,name=class,proto3,enum=train.SeatClass" json:"class,omitempty"`
+	// Set while the ticket has no seat because it was sold beyond the
+	// capacity of this section; seat is empty until an agent resolves it.
+	OverbookedSection string `protobuf:"bytes,12,opt,name=overbooked_section,json=overbookedSection,proto3" json:"overbooked_section,omitempty"`
+}
+
+func (x *Receipt) Reset() {
+	*x = Receipt{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Receipt) ProtoMessage() {}
+
+func (x *Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
+func (*Receipt) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Receipt) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Receipt) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *Receipt) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Receipt) GetPricePaid() float64 {
+	if x != nil {
+		return x.PricePaid
+	}
+	return 0
+}
+
+func (x *Receipt) GetSeat() string {
+	if x != nil {
+		return x.Seat
+	}
+	return ""
+}
+
+func (x *Receipt) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *Receipt) GetState() TicketState {
+	if x != nil {
+		return x.State
+	}
+	return TicketState_TICKET_STATE_UNSPECIFIED
+}
+
+func (x *Receipt) GetHistory() []*StateChange {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Receipt) GetHonouredPreferences() *SeatPreferences {
+	if x != nil {
+		return x.HonouredPreferences
+	}
+	return nil
+}
+
+func (x *Receipt) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *Receipt) GetClass() SeatClass {
+	if x != nil {
+		return x.Class
+	}
+	return SeatClass_SEAT_CLASS_UNSPECIFIED
+}
+
+func (x *Receipt) GetOverbookedSection() string {
+	if x != nil {
+		return x.OverbookedSection
+	}
+	return ""
+}
+
+// The request message for upgrading a ticket.
+type UpgradeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Email address of the passenger.
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Class to move to; must be higher than the current one.
+	Class SeatClass `protobuf:"varint,2,opt,name=class,proto3,enum=train.SeatClass" json:"class,omitempty"`
+}
+
+func (x *UpgradeRequest) Reset() {
+	*x = UpgradeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeRequest) ProtoMessage() {}
+
+func (x *UpgradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeRequest.ProtoReflect.Descriptor instead.
+func (*UpgradeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpgradeRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UpgradeRequest) GetClass() SeatClass {
+	if x != nil {
+		return x.Class
+	}
+	return SeatClass_SEAT_CLASS_UNSPECIFIED
+}
+
+// The outcome of an upgrade.
+type UpgradeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ticket with its new seat, class and price.
+	Receipt *Receipt `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	// Difference in fares charged for the upgrade.
+	Charged float64 `protobuf:"fixed64,2,opt,name=charged,proto3" json:"charged,omitempty"`
+}
+
+func (x *UpgradeResponse) Reset() {
+	*x = UpgradeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpgradeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpgradeResponse) ProtoMessage() {}
+
+func (x *UpgradeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpgradeResponse.ProtoReflect.Descriptor instead.
+func (*UpgradeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpgradeResponse) GetReceipt() *Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+func (x *UpgradeResponse) GetCharged() float64 {
+	if x != nil {
+		return x.Charged
+	}
+	return 0
+}
+
+// A ticket entering a state.
+type StateChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The state entered.
+	State TicketState `protobuf:"varint,1,opt,name=state,proto3,enum=train.TicketState" json:"state,omitempty"`
+	// When it was entered.
+	Time *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *StateChange) Reset() {
+	*x = StateChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StateChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StateChange) ProtoMessage() {}
+
+func (x *StateChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StateChange.ProtoReflect.Descriptor instead.
+func (*StateChange) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StateChange) GetState() TicketState {
+	if x != nil {
+		return x.State
+	}
+	return TicketState_TICKET_STATE_UNSPECIFIED
+}
+
+func (x *StateChange) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+// The user information.
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FirstName string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	// Email address; identifies the passenger's ticket.
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// Assistance the passenger needs while travelling.
+	AccessibilityNeeds []AccessibilityNeed `protobuf:"varint,4,rep,packed,name=accessibility_needs,json=accessibilityNeeds,proto3,enum=train.AccessibilityNeed" json:"accessibility_needs,omitempty"`
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *User) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *User) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetAccessibilityNeeds() []AccessibilityNeed {
+	if x != nil {
+		return x.AccessibilityNeeds
+	}
+	return nil
+}
+
+// The request message for user receipt.
+type UserRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Email address of the passenger.
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+}
+
+func (x *UserRequest) Reset() {
+	*x = UserRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserRequest) ProtoMessage() {}
+
+func (x *UserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserRequest.ProtoReflect.Descriptor instead.
+func (*UserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UserRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+// The request message for viewing seats.
+type SectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Train section, e.g. "A". ViewSeats lists every section when empty.
+	Section string `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	// ViewSeats only lists tickets in these states; all states when empty.
+	States []TicketState `protobuf:"varint,2,rep,packed,name=states,proto3,enum=train.TicketState" json:"states,omitempty"`
+	// ViewSeats only lists seats of this class; all classes when unspecified.
+	Class SeatClass `protobuf:"varint,3,opt,name=class,proto3,enum=train.SeatClass" json:"class,omitempty"`
+	// Maximum number of tickets ViewSeats returns; zero or more than 1000
+	// means 1000.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from the previous page, to continue after it.
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *SectionRequest) Reset() {
+	*x = SectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SectionRequest) ProtoMessage() {}
+
+func (x *SectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SectionRequest.ProtoReflect.Descriptor instead.
+func (*SectionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SectionRequest) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *SectionRequest) GetStates() []TicketState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *SectionRequest) GetClass() SeatClass {
+	if x != nil {
+		return x.Class
+	}
+	return SeatClass_SEAT_CLASS_UNSPECIFIED
+}
+
+func (x *SectionRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SectionRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// The response message for viewing seats.
+type SeatResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Passengers on this page, in the same order as seats. Kept for clients
+	// that predate seats.
+	Users []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Tickets on this page, ordered by section and seat. Overbooked tickets
+	// follow the seats of their section.
+	Seats []*BookedSeat `protobuf:"bytes,2,rep,name=seats,proto3" json:"seats,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *SeatResponse) Reset() {
+	*x = SeatResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatResponse) ProtoMessage() {}
+
+func (x *SeatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatResponse.ProtoReflect.Descriptor instead.
+func (*SeatResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SeatResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *SeatResponse) GetSeats() []*BookedSeat {
+	if x != nil {
+		return x.Seats
+	}
+	return nil
+}
+
+func (x *SeatResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// A ticket listed by ViewSeats.
+type BookedSeat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Seat identifier, e.g. "A-3"; empty if the ticket is overbooked.
+	Seat string `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	// Section the seat, or overbooked ticket, is in.
+	Section string `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	// The passenger holding the ticket.
+	User *User `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	// Reference quoted by the passenger.
+	BookingReference string `protobuf:"bytes,4,opt,name=booking_reference,json=bookingReference,proto3" json:"booking_reference,omitempty"`
+	// Where the ticket is in its lifecycle.
+	Status TicketState `protobuf:"varint,5,opt,name=status,proto3,enum=train.TicketState" json:"status,omitempty"`
+	// Class of the ticket.
+	Class SeatClass `protobuf:"varint,6,opt,name=class,proto3,enum=train.SeatClass" json:"class,omitempty"`
+}
+
+func (x *BookedSeat) Reset() {
+	*x = BookedSeat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookedSeat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookedSeat) ProtoMessage() {}
+
+func (x *BookedSeat) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookedSeat.ProtoReflect.Descriptor instead.
+func (*BookedSeat) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BookedSeat) GetSeat() string {
+	if x != nil {
+		return x.Seat
+	}
+	return ""
+}
+
+func (x *BookedSeat) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *BookedSeat) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *BookedSeat) GetBookingReference() string {
+	if x != nil {
+		return x.BookingReference
+	}
+	return ""
+}
+
+func (x *BookedSeat) GetStatus() TicketState {
+	if x != nil {
+		return x.Status
+	}
+	return TicketState_TICKET_STATE_UNSPECIFIED
+}
+
+func (x *BookedSeat) GetClass() SeatClass {
+	if x != nil {
+		return x.Class
+	}
+	return SeatClass_SEAT_CLASS_UNSPECIFIED
+}
+
+// The response message for status.
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Human readable outcome of the request.
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// The request message for modifying the seat.
+type ModifySeatRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Email address of the passenger.
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Seat to move the passenger to.
+	NewSeat string `protobuf:"bytes,2,opt,name=new_seat,json=newSeat,proto3" json:"new_seat,omitempty"`
+}
+
+func (x *ModifySeatRequest) Reset() {
+	*x = ModifySeatRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ModifySeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifySeatRequest) ProtoMessage() {}
+
+func (x *ModifySeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifySeatRequest.ProtoReflect.Descriptor instead.
+func (*ModifySeatRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ModifySeatRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ModifySeatRequest) GetNewSeat() string {
+	if x != nil {
+		return x.NewSeat
+	}
+	return ""
+}
+
+// A seat and the passenger holding it.
+type SeatAssignment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Seat identifier, e.g. "A-3".
+	Seat string `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	// The passenger in the seat.
+	User *User `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
 }
 
-func (x *PurchaseRequest) Reset() {
-	*x = PurchaseRequest{}
+func (x *SeatAssignment) Reset() {
+	*x = SeatAssignment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[0]
+		mi := &file_proto_ticketing_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PurchaseRequest) String() string {
+func (x *SeatAssignment) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PurchaseRequest) ProtoMessage() {}
+func (*SeatAssignment) ProtoMessage() {}
 
-func (x *PurchaseRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[0]
+func (x *SeatAssignment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,69 +1470,132 @@ func (x *PurchaseRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PurchaseRequest.ProtoReflect.Descriptor instead.
-func (*PurchaseRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use SeatAssignment.ProtoReflect.Descriptor instead.
+func (*SeatAssignment) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *PurchaseRequest) GetFrom() string {
+func (x *SeatAssignment) GetSeat() string {
 	if x != nil {
-		return x.From
+		return x.Seat
 	}
 	return ""
 }
 
-func (x *PurchaseRequest) GetTo() string {
+func (x *SeatAssignment) GetUser() *User {
 	if x != nil {
-		return x.To
+		return x.User
+	}
+	return nil
+}
+
+// An update to the occupancy of a section.
+type SeatEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// What happened.
+	Kind SeatEvent_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=train.SeatEvent_Kind" json:"kind,omitempty"`
+	// Section the event applies to.
+	Section string `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	// Every occupied seat; set on SNAPSHOT events.
+	Seats []*SeatAssignment `protobuf:"bytes,3,rep,name=seats,proto3" json:"seats,omitempty"`
+	// The seat that changed; set on SEAT_TAKEN and SEAT_FREED events.
+	Seat *SeatAssignment `protobuf:"bytes,4,opt,name=seat,proto3" json:"seat,omitempty"`
+}
+
+func (x *SeatEvent) Reset() {
+	*x = SeatEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeatEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatEvent) ProtoMessage() {}
+
+func (x *SeatEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatEvent.ProtoReflect.Descriptor instead.
+func (*SeatEvent) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SeatEvent) GetKind() SeatEvent_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return SeatEvent_KIND_UNSPECIFIED
+}
+
+func (x *SeatEvent) GetSection() string {
+	if x != nil {
+		return x.Section
 	}
 	return ""
 }
 
-func (x *PurchaseRequest) GetUser() *User {
+func (x *SeatEvent) GetSeats() []*SeatAssignment {
 	if x != nil {
-		return x.User
+		return x.Seats
 	}
 	return nil
 }
 
-func (x *PurchaseRequest) GetPricePaid() float64 {
+func (x *SeatEvent) GetSeat() *SeatAssignment {
 	if x != nil {
-		return x.PricePaid
+		return x.Seat
 	}
-	return 0
+	return nil
 }
 
-// The response message containing the receipt details.
-type Receipt struct {
+// The request message for a printable ticket.
+type TicketDocumentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	From      string  `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To        string  `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
-	User      *User   `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
-	PricePaid float64 `protobuf:"fixed64,4,opt,name=price_paid,json=pricePaid,proto3" json:"price_paid,omitempty"`
-	Seat      string  `protobuf:"bytes,5,opt,name=seat,proto3" json:"seat,omitempty"`
+	// Email address of the passenger.
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Document format; PDF when unspecified.
+	Format TicketDocumentRequest_Format `protobuf:"varint,2,opt,name=format,proto3,enum=train.TicketDocumentRequest_Format" json:"format,omitempty"`
+	// Brand whose templates are used; the default brand when empty.
+	Brand string `protobuf:"bytes,3,opt,name=brand,proto3" json:"brand,omitempty"`
 }
 
-func (x *Receipt) Reset() {
-	*x = Receipt{}
+func (x *TicketDocumentRequest) Reset() {
+	*x = TicketDocumentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[1]
+		mi := &file_proto_ticketing_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Receipt) String() string {
+func (x *TicketDocumentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Receipt) ProtoMessage() {}
+func (*TicketDocumentRequest) ProtoMessage() {}
 
-func (x *Receipt) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[1]
+func (x *TicketDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -132,74 +1606,128 @@ func (x *Receipt) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Receipt.ProtoReflect.Descriptor instead.
-func (*Receipt) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use TicketDocumentRequest.ProtoReflect.Descriptor instead.
+func (*TicketDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *Receipt) GetFrom() string {
+func (x *TicketDocumentRequest) GetEmail() string {
 	if x != nil {
-		return x.From
+		return x.Email
 	}
 	return ""
 }
 
-func (x *Receipt) GetTo() string {
+func (x *TicketDocumentRequest) GetFormat() TicketDocumentRequest_Format {
 	if x != nil {
-		return x.To
+		return x.Format
+	}
+	return TicketDocumentRequest_FORMAT_UNSPECIFIED
+}
+
+func (x *TicketDocumentRequest) GetBrand() string {
+	if x != nil {
+		return x.Brand
 	}
 	return ""
 }
 
-func (x *Receipt) GetUser() *User {
+// A rendered ticket.
+type TicketDocument struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// MIME type of content, e.g. "application/pdf".
+	ContentType string `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// The document itself.
+	Content []byte `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// Suggested file name for downloads.
+	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+}
+
+func (x *TicketDocument) Reset() {
+	*x = TicketDocument{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TicketDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TicketDocument) ProtoMessage() {}
+
+func (x *TicketDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TicketDocument.ProtoReflect.Descriptor instead.
+func (*TicketDocument) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TicketDocument) GetContentType() string {
 	if x != nil {
-		return x.User
+		return x.ContentType
 	}
-	return nil
+	return ""
 }
 
-func (x *Receipt) GetPricePaid() float64 {
+func (x *TicketDocument) GetContent() []byte {
 	if x != nil {
-		return x.PricePaid
+		return x.Content
 	}
-	return 0
+	return nil
 }
 
-func (x *Receipt) GetSeat() string {
+func (x *TicketDocument) GetFilename() string {
 	if x != nil {
-		return x.Seat
+		return x.Filename
 	}
 	return ""
 }
 
-// The user information.
-type User struct {
+// A signed boarding pass.
+type BoardingPass struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FirstName string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
-	LastName  string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
-	Email     string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// Signed pass contents; verifiable offline with the issuer's public key.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// The code as a PNG QR code.
+	QrPng []byte `protobuf:"bytes,2,opt,name=qr_png,json=qrPng,proto3" json:"qr_png,omitempty"`
 }
 
-func (x *User) Reset() {
-	*x = User{}
+func (x *BoardingPass) Reset() {
+	*x = BoardingPass{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[2]
+		mi := &file_proto_ticketing_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *User) String() string {
+func (x *BoardingPass) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*BoardingPass) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[2]
+func (x *BoardingPass) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -210,58 +1738,103 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use BoardingPass.ProtoReflect.Descriptor instead.
+func (*BoardingPass) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *User) GetFirstName() string {
+func (x *BoardingPass) GetCode() string {
 	if x != nil {
-		return x.FirstName
+		return x.Code
 	}
 	return ""
 }
 
-func (x *User) GetLastName() string {
+func (x *BoardingPass) GetQrPng() []byte {
 	if x != nil {
-		return x.LastName
+		return x.QrPng
 	}
-	return ""
+	return nil
 }
 
-func (x *User) GetEmail() string {
+// The request message for checking a boarding pass.
+type ValidateBoardingPassRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Code read from the boarding pass.
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *ValidateBoardingPassRequest) Reset() {
+	*x = ValidateBoardingPassRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_ticketing_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateBoardingPassRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBoardingPassRequest) ProtoMessage() {}
+
+func (x *ValidateBoardingPassRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBoardingPassRequest.ProtoReflect.Descriptor instead.
+func (*ValidateBoardingPassRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ValidateBoardingPassRequest) GetCode() string {
 	if x != nil {
-		return x.Email
+		return x.Code
 	}
 	return ""
 }
 
-// The request message for user receipt.
-type UserRequest struct {
+// The outcome of checking a boarding pass.
+type ValidateBoardingPassResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// Whether the passenger may board, and why not.
+	Result ValidateBoardingPassResponse_Result `protobuf:"varint,1,opt,name=result,proto3,enum=train.ValidateBoardingPassResponse_Result" json:"result,omitempty"`
+	// The ticket as written on the pass; unset when TAMPERED.
+	Ticket *Receipt `protobuf:"bytes,2,opt,name=ticket,proto3" json:"ticket,omitempty"`
 }
 
-func (x *UserRequest) Reset() {
-	*x = UserRequest{}
+func (x *ValidateBoardingPassResponse) Reset() {
+	*x = ValidateBoardingPassResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[3]
+		mi := &file_proto_ticketing_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UserRequest) String() string {
+func (x *ValidateBoardingPassResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserRequest) ProtoMessage() {}
+func (*ValidateBoardingPassResponse) ProtoMessage() {}
 
-func (x *UserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[3]
+func (x *ValidateBoardingPassResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -272,44 +1845,53 @@ func (x *UserRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserRequest.ProtoReflect.Descriptor instead.
-func (*UserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ValidateBoardingPassResponse.ProtoReflect.Descriptor instead.
+func (*ValidateBoardingPassResponse) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *UserRequest) GetEmail() string {
+func (x *ValidateBoardingPassResponse) GetResult() ValidateBoardingPassResponse_Result {
 	if x != nil {
-		return x.Email
+		return x.Result
 	}
-	return ""
+	return ValidateBoardingPassResponse_RESULT_UNSPECIFIED
 }
 
-// The request message for viewing seats.
-type SectionRequest struct {
+func (x *ValidateBoardingPassResponse) GetTicket() *Receipt {
+	if x != nil {
+		return x.Ticket
+	}
+	return nil
+}
+
+// The request message for a seat map.
+type SeatMapRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Section string `protobuf:"bytes,1,opt,name=section,proto3" json:"section,omitempty"`
+	// Journey the map is for. The service runs a single train, so every
+	// journey currently shares one map.
+	Journey string `protobuf:"bytes,1,opt,name=journey,proto3" json:"journey,omitempty"`
 }
 
-func (x *SectionRequest) Reset() {
-	*x = SectionRequest{}
+func (x *SeatMapRequest) Reset() {
+	*x = SeatMapRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[4]
+		mi := &file_proto_ticketing_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SectionRequest) String() string {
+func (x *SeatMapRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SectionRequest) ProtoMessage() {}
+func (*SeatMapRequest) ProtoMessage() {}
 
-func (x *SectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[4]
+func (x *SeatMapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -320,44 +1902,45 @@ func (x *SectionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SectionRequest.ProtoReflect.Descriptor instead.
-func (*SectionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SeatMapRequest.ProtoReflect.Descriptor instead.
+func (*SeatMapRequest) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *SectionRequest) GetSection() string {
+func (x *SeatMapRequest) GetJourney() string {
 	if x != nil {
-		return x.Section
+		return x.Journey
 	}
 	return ""
 }
 
-// The response message for viewing seats.
-type SeatResponse struct {
+// Every seat on the train.
+type SeatMap struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Users []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Seats ordered by section, then number.
+	Seats []*SeatInfo `protobuf:"bytes,1,rep,name=seats,proto3" json:"seats,omitempty"`
 }
 
-func (x *SeatResponse) Reset() {
-	*x = SeatResponse{}
+func (x *SeatMap) Reset() {
+	*x = SeatMap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[5]
+		mi := &file_proto_ticketing_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SeatResponse) String() string {
+func (x *SeatMap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SeatResponse) ProtoMessage() {}
+func (*SeatMap) ProtoMessage() {}
 
-func (x *SeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[5]
+func (x *SeatMap) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -368,44 +1951,53 @@ func (x *SeatResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SeatResponse.ProtoReflect.Descriptor instead.
-func (*SeatResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SeatMap.ProtoReflect.Descriptor instead.
+func (*SeatMap) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *SeatResponse) GetUsers() []*User {
+func (x *SeatMap) GetSeats() []*SeatInfo {
 	if x != nil {
-		return x.Users
+		return x.Seats
 	}
 	return nil
 }
 
-// The response message for status.
-type StatusResponse struct {
+// One seat on a seat map.
+type SeatInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Seat identifier, e.g. "A-3".
+	Seat string `protobuf:"bytes,1,opt,name=seat,proto3" json:"seat,omitempty"`
+	// Section the seat is in.
+	Section string `protobuf:"bytes,2,opt,name=section,proto3" json:"section,omitempty"`
+	// Whether the seat can be booked.
+	Status SeatStatus `protobuf:"varint,3,opt,name=status,proto3,enum=train.SeatStatus" json:"status,omitempty"`
+	// Where the seat is in its carriage.
+	Attributes *SeatAttributes `protobuf:"bytes,4,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// Class of the seat.
+	Class SeatClass `protobuf:"varint,5,opt,name=class,proto3,enum=train.SeatClass" json:"class,omitempty"`
 }
 
-func (x *StatusResponse) Reset() {
-	*x = StatusResponse{}
+func (x *SeatInfo) Reset() {
+	*x = SeatInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[6]
+		mi := &file_proto_ticketing_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusResponse) String() string {
+func (x *SeatInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse) ProtoMessage() {}
+func (*SeatInfo) ProtoMessage() {}
 
-func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[6]
+func (x *SeatInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,45 +2008,83 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
-func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SeatInfo.ProtoReflect.Descriptor instead.
+func (*SeatInfo) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *StatusResponse) GetMessage() string {
+func (x *SeatInfo) GetSeat() string {
 	if x != nil {
-		return x.Message
+		return x.Seat
 	}
 	return ""
 }
 
-// The request message for modifying the seat.
-type ModifySeatRequest struct {
+func (x *SeatInfo) GetSection() string {
+	if x != nil {
+		return x.Section
+	}
+	return ""
+}
+
+func (x *SeatInfo) GetStatus() SeatStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SeatStatus_SEAT_STATUS_UNSPECIFIED
+}
+
+func (x *SeatInfo) GetAttributes() *SeatAttributes {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *SeatInfo) GetClass() SeatClass {
+	if x != nil {
+		return x.Class
+	}
+	return SeatClass_SEAT_CLASS_UNSPECIFIED
+}
+
+// Where a seat is in its carriage.
+type SeatAttributes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email   string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	NewSeat string `protobuf:"bytes,2,opt,name=new_seat,json=newSeat,proto3" json:"new_seat,omitempty"`
+	// The seat is next to the window.
+	Window bool `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"`
+	// The seat is next to the aisle.
+	Aisle bool `protobuf:"varint,2,opt,name=aisle,proto3" json:"aisle,omitempty"`
+	// The seat faces the direction of travel.
+	ForwardFacing bool `protobuf:"varint,3,opt,name=forward_facing,json=forwardFacing,proto3" json:"forward_facing,omitempty"`
+	// The seat is in a quiet coach.
+	QuietCoach bool `protobuf:"varint,4,opt,name=quiet_coach,json=quietCoach,proto3" json:"quiet_coach,omitempty"`
+	// The seat is a wheelchair space.
+	WheelchairSpace bool `protobuf:"varint,5,opt,name=wheelchair_space,json=wheelchairSpace,proto3" json:"wheelchair_space,omitempty"`
+	// The seat is kept for companions of wheelchair users.
+	CompanionSeat bool `protobuf:"varint,6,opt,name=companion_seat,json=companionSeat,proto3" json:"companion_seat,omitempty"`
 }
 
-func (x *ModifySeatRequest) Reset() {
-	*x = ModifySeatRequest{}
+func (x *SeatAttributes) Reset() {
+	*x = SeatAttributes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_ticketing_proto_msgTypes[7]
+		mi := &file_proto_ticketing_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ModifySeatRequest) String() string {
+func (x *SeatAttributes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ModifySeatRequest) ProtoMessage() {}
+func (*SeatAttributes) ProtoMessage() {}
 
-func (x *ModifySeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_ticketing_proto_msgTypes[7]
+func (x *SeatAttributes) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_ticketing_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -465,88 +2095,358 @@ func (x *ModifySeatRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ModifySeatRequest.ProtoReflect.Descriptor instead.
-func (*ModifySeatRequest) Descriptor() ([]byte, []int) {
-	return file_proto_ticketing_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SeatAttributes.ProtoReflect.Descriptor instead.
+func (*SeatAttributes) Descriptor() ([]byte, []int) {
+	return file_proto_ticketing_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *ModifySeatRequest) GetEmail() string {
+func (x *SeatAttributes) GetWindow() bool {
 	if x != nil {
-		return x.Email
+		return x.Window
 	}
-	return ""
+	return false
 }
 
-func (x *ModifySeatRequest) GetNewSeat() string {
+func (x *SeatAttributes) GetAisle() bool {
 	if x != nil {
-		return x.NewSeat
+		return x.Aisle
 	}
-	return ""
+	return false
+}
+
+func (x *SeatAttributes) GetForwardFacing() bool {
+	if x != nil {
+		return x.ForwardFacing
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetQuietCoach() bool {
+	if x != nil {
+		return x.QuietCoach
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetWheelchairSpace() bool {
+	if x != nil {
+		return x.WheelchairSpace
+	}
+	return false
+}
+
+func (x *SeatAttributes) GetCompanionSeat() bool {
+	if x != nil {
+		return x.CompanionSeat
+	}
+	return false
 }
 
 var File_proto_ticketing_proto protoreflect.FileDescriptor
 
 var file_proto_ticketing_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e,
-	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x22, 0x75,
-	0x0a, 0x0f, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1f, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f,
-	0x70, 0x61, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63,
-	0x65, 0x50, 0x61, 0x69, 0x64, 0x22, 0x81, 0x01, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1f, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x1a, 0x1f,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x94, 0x02, 0x0a, 0x0f, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1f, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x72,
+	0x69, 0x63, 0x65, 0x50, 0x61, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x0b, 0x70, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x73,
+	0x65, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x53, 0x65, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x26,
+	0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52,
+	0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x22, 0x87, 0x01, 0x0a, 0x0f, 0x53, 0x65, 0x61, 0x74, 0x50,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69,
+	0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64,
+	0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x69, 0x73, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x61, 0x69, 0x73, 0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x5f, 0x66, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x46, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12,
+	0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x69, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x61, 0x63, 0x68, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x71, 0x75, 0x69, 0x65, 0x74, 0x43, 0x6f, 0x61, 0x63, 0x68,
+	0x22, 0xbe, 0x03, 0x0a, 0x07, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d,
+	0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f,
+	0x12, 0x1f, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65,
+	0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x61, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63, 0x65, 0x50, 0x61, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x73, 0x65, 0x61, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x5f,
+	0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x49, 0x0a, 0x14, 0x68, 0x6f, 0x6e,
+	0x6f, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e,
+	0x53, 0x65, 0x61, 0x74, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x52,
+	0x13, 0x68, 0x6f, 0x6e, 0x6f, 0x75, 0x72, 0x65, 0x64, 0x50, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x26, 0x0a, 0x05, 0x63, 0x6c,
+	0x61, 0x73, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x6f, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x65, 0x64,
+	0x5f, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x6f, 0x76, 0x65, 0x72, 0x62, 0x6f, 0x6f, 0x6b, 0x65, 0x64, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x4e, 0x0a, 0x0e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x26, 0x0a, 0x05, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x2e, 0x53, 0x65, 0x61, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x22, 0x55, 0x0a, 0x0f, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x70, 0x74, 0x52, 0x07, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x07, 0x63, 0x68, 0x61, 0x72, 0x67, 0x65, 0x64, 0x22, 0x67, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x22, 0xa3, 0x01, 0x0a, 0x04, 0x55, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x49, 0x0a, 0x13,
+	0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x6e, 0x65,
+	0x65, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4e,
+	0x65, 0x65, 0x64, 0x52, 0x12, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x4e, 0x65, 0x65, 0x64, 0x73, 0x22, 0x23, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0xba, 0x01, 0x0a,
+	0x0e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61,
+	0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1b, 0x0a,
+	0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x82, 0x01, 0x0a, 0x0c, 0x53, 0x65,
+	0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x27, 0x0a,
+	0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x65, 0x64, 0x53, 0x65, 0x61, 0x74, 0x52,
+	0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70,
+	0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xdc,
+	0x01, 0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x6b, 0x65, 0x64, 0x53, 0x65, 0x61, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x65, 0x61,
+	0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11,
+	0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x62, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61,
+	0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x22, 0x2a, 0x0a,
+	0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x11, 0x4d, 0x6f, 0x64,
+	0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x73, 0x65, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x53, 0x65, 0x61, 0x74, 0x22,
+	0x45, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x73, 0x65, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f,
-	0x70, 0x61, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x70, 0x72, 0x69, 0x63,
-	0x65, 0x50, 0x61, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x65, 0x61, 0x74, 0x22, 0x58, 0x0a, 0x04, 0x55, 0x73, 0x65,
-	0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
-	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
-	0x61, 0x69, 0x6c, 0x22, 0x23, 0x0a, 0x0b, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x2a, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x31, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72,
-	0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x22, 0x2a, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x11, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x19,
-	0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6e, 0x65, 0x77, 0x53, 0x65, 0x61, 0x74, 0x32, 0xac, 0x02, 0x0a, 0x0d, 0x54, 0x69,
-	0x63, 0x6b, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x0e, 0x50,
-	0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x2e,
-	0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65,
-	0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x63, 0x65,
-	0x69, 0x70, 0x74, 0x12, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72,
+	0x52, 0x04, 0x75, 0x73, 0x65, 0x72, 0x22, 0xf4, 0x01, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x65, 0x61,
+	0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x2e, 0x53, 0x65, 0x61, 0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x04, 0x73, 0x65, 0x61, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61,
+	0x74, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x73, 0x65, 0x61,
+	0x74, 0x22, 0x4a, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e,
+	0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x0c, 0x0a, 0x08, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a,
+	0x0a, 0x53, 0x45, 0x41, 0x54, 0x5f, 0x54, 0x41, 0x4b, 0x45, 0x4e, 0x10, 0x02, 0x12, 0x0e, 0x0a,
+	0x0a, 0x53, 0x45, 0x41, 0x54, 0x5f, 0x46, 0x52, 0x45, 0x45, 0x44, 0x10, 0x03, 0x22, 0xb5, 0x01,
+	0x0a, 0x15, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x3b, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x23, 0x2e,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x72,
+	0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x62, 0x72, 0x61, 0x6e, 0x64,
+	0x22, 0x33, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x12, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x44, 0x46, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x48,
+	0x54, 0x4d, 0x4c, 0x10, 0x02, 0x22, 0x69, 0x0a, 0x0e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x44,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65,
+	0x22, 0x39, 0x0a, 0x0c, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x73, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x71, 0x72, 0x5f, 0x70, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x71, 0x72, 0x50, 0x6e, 0x67, 0x22, 0x31, 0x0a, 0x1b, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x50,
+	0x61, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x8d,
+	0x02, 0x0a, 0x1c, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64,
+	0x69, 0x6e, 0x67, 0x50, 0x61, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x42, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x2a, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x42, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x52, 0x06, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x80, 0x01, 0x0a, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x12, 0x52, 0x45, 0x53, 0x55, 0x4c, 0x54,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x41, 0x4d,
+	0x50, 0x45, 0x52, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x41, 0x4e, 0x43, 0x45,
+	0x4c, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44,
+	0x59, 0x5f, 0x53, 0x43, 0x41, 0x4e, 0x4e, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x53,
+	0x55, 0x50, 0x45, 0x52, 0x53, 0x45, 0x44, 0x45, 0x44, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x4e,
+	0x4f, 0x54, 0x5f, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x06, 0x22, 0x2a,
+	0x0a, 0x0e, 0x53, 0x65, 0x61, 0x74, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6a, 0x6f, 0x75, 0x72, 0x6e, 0x65, 0x79, 0x22, 0x30, 0x0a, 0x07, 0x53, 0x65,
+	0x61, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x25, 0x0a, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x73, 0x65, 0x61, 0x74, 0x73, 0x22, 0xc2, 0x01, 0x0a,
+	0x08, 0x53, 0x65, 0x61, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x61,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x65, 0x61, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e,
+	0x53, 0x65, 0x61, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53,
+	0x65, 0x61, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x52, 0x0a, 0x61,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x63, 0x6c, 0x61,
+	0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x2e, 0x53, 0x65, 0x61, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73,
+	0x73, 0x22, 0xd8, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x74, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05,
+	0x61, 0x69, 0x73, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x69, 0x73,
+	0x6c, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x5f, 0x66, 0x61,
+	0x63, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x77,
+	0x61, 0x72, 0x64, 0x46, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x69,
+	0x65, 0x74, 0x5f, 0x63, 0x6f, 0x61, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x71, 0x75, 0x69, 0x65, 0x74, 0x43, 0x6f, 0x61, 0x63, 0x68, 0x12, 0x29, 0x0a, 0x10, 0x77, 0x68,
+	0x65, 0x65, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x72, 0x5f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x77, 0x68, 0x65, 0x65, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x72,
+	0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69,
+	0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x61, 0x74, 0x2a, 0x4e, 0x0a, 0x09,
+	0x53, 0x65, 0x61, 0x74, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x45, 0x41,
+	0x54, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x41, 0x52,
+	0x44, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x42, 0x55, 0x53, 0x49, 0x4e, 0x45, 0x53, 0x53, 0x10,
+	0x02, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x03, 0x2a, 0x8a, 0x01, 0x0a,
+	0x0b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x18,
+	0x54, 0x49, 0x43, 0x4b, 0x45, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45,
+	0x53, 0x45, 0x52, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x41, 0x49, 0x44,
+	0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x48, 0x45, 0x43, 0x4b, 0x45, 0x44, 0x5f, 0x49, 0x4e,
+	0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4f, 0x41, 0x52, 0x44, 0x45, 0x44, 0x10, 0x04, 0x12,
+	0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x5f, 0x53, 0x48, 0x4f, 0x57, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09,
+	0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x52,
+	0x45, 0x46, 0x55, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x07, 0x2a, 0x7a, 0x0a, 0x11, 0x41, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x4e, 0x65, 0x65, 0x64, 0x12, 0x22,
+	0x0a, 0x1e, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x49, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f,
+	0x4e, 0x45, 0x45, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x57, 0x48, 0x45, 0x45, 0x4c, 0x43, 0x48, 0x41, 0x49, 0x52,
+	0x5f, 0x53, 0x50, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x4f, 0x4d, 0x50,
+	0x41, 0x4e, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x41, 0x54, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13,
+	0x42, 0x4f, 0x41, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x53, 0x53, 0x49, 0x53, 0x54, 0x41,
+	0x4e, 0x43, 0x45, 0x10, 0x03, 0x2a, 0x54, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x41, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54,
+	0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x46, 0x52, 0x45, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x45,
+	0x4c, 0x44, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x4f, 0x4c, 0x44, 0x10, 0x03, 0x12, 0x0b,
+	0x0a, 0x07, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x04, 0x32, 0x9d, 0x06, 0x0a, 0x0d,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a,
+	0x0e, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x16, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x50, 0x75, 0x72, 0x63, 0x68, 0x61, 0x73, 0x65,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e,
-	0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x56, 0x69, 0x65, 0x77, 0x53,
-	0x65, 0x61, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x72,
-	0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x37, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72, 0x12, 0x12,
-	0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0a, 0x4d, 0x6f, 0x64,
-	0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x12, 0x18, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e,
-	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x15, 0x5a, 0x13, 0x74, 0x69, 0x63, 0x6b,
-	0x65, 0x74, 0x69, 0x6e, 0x67, 0x2d, 0x73, 0x76, 0x63, 0x2f, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x37, 0x0a, 0x09, 0x56, 0x69, 0x65,
+	0x77, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x37, 0x0a, 0x0a, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x55, 0x73, 0x65, 0x72,
+	0x12, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x0a, 0x4d,
+	0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x12, 0x18, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x0a, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x2e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x2e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x54,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3a, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x73, 0x73,
+	0x12, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x42, 0x6f, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x73, 0x73, 0x12, 0x5f, 0x0a, 0x14, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x73,
+	0x73, 0x12, 0x22, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61, 0x73, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x61,
+	0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x49, 0x6e, 0x12, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x72, 0x61, 0x69,
+	0x6e, 0x2e, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x42, 0x6f, 0x61,
+	0x72, 0x64, 0x12, 0x12, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x33, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x53, 0x65, 0x61,
+	0x74, 0x4d, 0x61, 0x70, 0x12, 0x15, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61,
+	0x74, 0x4d, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x4d, 0x61, 0x70, 0x12, 0x3e, 0x0a, 0x0d, 0x55,
+	0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x15, 0x2e, 0x74,
+	0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x2e, 0x55, 0x70, 0x67, 0x72,
+	0x61, 0x64, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x15, 0x5a, 0x13, 0x74,
+	0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x2d, 0x73, 0x76, 0x63, 0x2f, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -561,36 +2461,105 @@ func file_proto_ticketing_proto_rawDescGZIP() []byte {
 	return file_proto_ticketing_proto_rawDescData
 }
 
-var file_proto_ticketing_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_ticketing_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_proto_ticketing_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_ticketing_proto_goTypes = []interface{}{
-	(*PurchaseRequest)(nil),   // 0: train.PurchaseRequest
-	(*Receipt)(nil),           // 1: train.Receipt
-	(*User)(nil),              // 2: train.User
-	(*UserRequest)(nil),       // 3: train.UserRequest
-	(*SectionRequest)(nil),    // 4: train.SectionRequest
-	(*SeatResponse)(nil),      // 5: train.SeatResponse
-	(*StatusResponse)(nil),    // 6: train.StatusResponse
-	(*ModifySeatRequest)(nil), // 7: train.ModifySeatRequest
+	(SeatClass)(0),                           // 0: train.SeatClass
+	(TicketState)(0),                         // 1: train.TicketState
+	(AccessibilityNeed)(0),                   // 2: train.AccessibilityNeed
+	(SeatStatus)(0),                          // 3: train.SeatStatus
+	(SeatEvent_Kind)(0),                      // 4: train.SeatEvent.Kind
+	(TicketDocumentRequest_Format)(0),        // 5: train.TicketDocumentRequest.Format
+	(ValidateBoardingPassResponse_Result)(0), // 6: train.ValidateBoardingPassResponse.Result
+	(*PurchaseRequest)(nil),                  // 7: train.PurchaseRequest
+	(*SeatPreferences)(nil),                  // 8: train.SeatPreferences
+	(*Receipt)(nil),                          // 9: train.Receipt
+	(*UpgradeRequest)(nil),                   // 10: train.UpgradeRequest
+	(*UpgradeResponse)(nil),                  // 11: train.UpgradeResponse
+	(*StateChange)(nil),                      // 12: train.StateChange
+	(*User)(nil),                             // 13: train.User
+	(*UserRequest)(nil),                      // 14: train.UserRequest
+	(*SectionRequest)(nil),                   // 15: train.SectionRequest
+	(*SeatResponse)(nil),                     // 16: train.SeatResponse
+	(*BookedSeat)(nil),                       // 17: train.BookedSeat
+	(*StatusResponse)(nil),                   // 18: train.StatusResponse
+	(*ModifySeatRequest)(nil),                // 19: train.ModifySeatRequest
+	(*SeatAssignment)(nil),                   // 20: train.SeatAssignment
+	(*SeatEvent)(nil),                        // 21: train.SeatEvent
+	(*TicketDocumentRequest)(nil),            // 22: train.TicketDocumentRequest
+	(*TicketDocument)(nil),                   // 23: train.TicketDocument
+	(*BoardingPass)(nil),                     // 24: train.BoardingPass
+	(*ValidateBoardingPassRequest)(nil),      // 25: train.ValidateBoardingPassRequest
+	(*ValidateBoardingPassResponse)(nil),     // 26: train.ValidateBoardingPassResponse
+	(*SeatMapRequest)(nil),                   // 27: train.SeatMapRequest
+	(*SeatMap)(nil),                          // 28: train.SeatMap
+	(*SeatInfo)(nil),                         // 29: train.SeatInfo
+	(*SeatAttributes)(nil),                   // 30: train.SeatAttributes
+	(*timestamppb.Timestamp)(nil),            // 31: google.protobuf.Timestamp
 }
 var file_proto_ticketing_proto_depIdxs = []int32{
-	2, // 0: train.PurchaseRequest.user:type_name -> train.User
-	2, // 1: train.Receipt.user:type_name -> train.User
-	2, // 2: train.SeatResponse.users:type_name -> train.User
-	0, // 3: train.TicketService.PurchaseTicket:input_type -> train.PurchaseRequest
-	3, // 4: train.TicketService.GetReceipt:input_type -> train.UserRequest
-	4, // 5: train.TicketService.ViewSeats:input_type -> train.SectionRequest
-	3, // 6: train.TicketService.RemoveUser:input_type -> train.UserRequest
-	7, // 7: train.TicketService.ModifySeat:input_type -> train.ModifySeatRequest
-	1, // 8: train.TicketService.PurchaseTicket:output_type -> train.Receipt
-	1, // 9: train.TicketService.GetReceipt:output_type -> train.Receipt
-	5, // 10: train.TicketService.ViewSeats:output_type -> train.SeatResponse
-	6, // 11: train.TicketService.RemoveUser:output_type -> train.StatusResponse
-	6, // 12: train.TicketService.ModifySeat:output_type -> train.StatusResponse
-	8, // [8:13] is the sub-list for method output_type
-	3, // [3:8] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	13, // 0: train.PurchaseRequest.user:type_name -> train.User
+	8,  // 1: train.PurchaseRequest.preferences:type_name -> train.SeatPreferences
+	0,  // 2: train.PurchaseRequest.class:type_name -> train.SeatClass
+	13, // 3: train.Receipt.user:type_name -> train.User
+	1,  // 4: train.Receipt.state:type_name -> train.TicketState
+	12, // 5: train.Receipt.history:type_name -> train.StateChange
+	8,  // 6: train.Receipt.honoured_preferences:type_name -> train.SeatPreferences
+	0,  // 7: train.Receipt.class:type_name -> train.SeatClass
+	0,  // 8: train.UpgradeRequest.class:type_name -> train.SeatClass
+	9,  // 9: train.UpgradeResponse.receipt:type_name -> train.Receipt
+	1,  // 10: train.StateChange.state:type_name -> train.TicketState
+	31, // 11: train.StateChange.time:type_name -> google.protobuf.Timestamp
+	2,  // 12: train.User.accessibility_needs:type_name -> train.AccessibilityNeed
+	1,  // 13: train.SectionRequest.states:type_name -> train.TicketState
+	0,  // 14: train.SectionRequest.class:type_name -> train.SeatClass
+	13, // 15: train.SeatResponse.users:type_name -> train.User
+	17, // 16: train.SeatResponse.seats:type_name -> train.BookedSeat
+	13, // 17: train.BookedSeat.user:type_name -> train.User
+	1,  // 18: train.BookedSeat.status:type_name -> train.TicketState
+	0,  // 19: train.BookedSeat.class:type_name -> train.SeatClass
+	13, // 20: train.SeatAssignment.user:type_name -> train.User
+	4,  // 21: train.SeatEvent.kind:type_name -> train.SeatEvent.Kind
+	20, // 22: train.SeatEvent.seats:type_name -> train.SeatAssignment
+	20, // 23: train.SeatEvent.seat:type_name -> train.SeatAssignment
+	5,  // 24: train.TicketDocumentRequest.format:type_name -> train.TicketDocumentRequest.Format
+	6,  // 25: train.ValidateBoardingPassResponse.result:type_name -> train.ValidateBoardingPassResponse.Result
+	9,  // 26: train.ValidateBoardingPassResponse.ticket:type_name -> train.Receipt
+	29, // 27: train.SeatMap.seats:type_name -> train.SeatInfo
+	3,  // 28: train.SeatInfo.status:type_name -> train.SeatStatus
+	30, // 29: train.SeatInfo.attributes:type_name -> train.SeatAttributes
+	0,  // 30: train.SeatInfo.class:type_name -> train.SeatClass
+	7,  // 31: train.TicketService.PurchaseTicket:input_type -> train.PurchaseRequest
+	14, // 32: train.TicketService.GetReceipt:input_type -> train.UserRequest
+	15, // 33: train.TicketService.ViewSeats:input_type -> train.SectionRequest
+	14, // 34: train.TicketService.RemoveUser:input_type -> train.UserRequest
+	19, // 35: train.TicketService.ModifySeat:input_type -> train.ModifySeatRequest
+	15, // 36: train.TicketService.WatchSeats:input_type -> train.SectionRequest
+	22, // 37: train.TicketService.GetTicketDocument:input_type -> train.TicketDocumentRequest
+	14, // 38: train.TicketService.GetBoardingPass:input_type -> train.UserRequest
+	25, // 39: train.TicketService.ValidateBoardingPass:input_type -> train.ValidateBoardingPassRequest
+	14, // 40: train.TicketService.CheckIn:input_type -> train.UserRequest
+	14, // 41: train.TicketService.Board:input_type -> train.UserRequest
+	27, // 42: train.TicketService.GetSeatMap:input_type -> train.SeatMapRequest
+	10, // 43: train.TicketService.UpgradeTicket:input_type -> train.UpgradeRequest
+	9,  // 44: train.TicketService.PurchaseTicket:output_type -> train.Receipt
+	9,  // 45: train.TicketService.GetReceipt:output_type -> train.Receipt
+	16, // 46: train.TicketService.ViewSeats:output_type -> train.SeatResponse
+	18, // 47: train.TicketService.RemoveUser:output_type -> train.StatusResponse
+	18, // 48: train.TicketService.ModifySeat:output_type -> train.StatusResponse
+	21, // 49: train.TicketService.WatchSeats:output_type -> train.SeatEvent
+	23, // 50: train.TicketService.GetTicketDocument:output_type -> train.TicketDocument
+	24, // 51: train.TicketService.GetBoardingPass:output_type -> train.BoardingPass
+	26, // 52: train.TicketService.ValidateBoardingPass:output_type -> train.ValidateBoardingPassResponse
+	9,  // 53: train.TicketService.CheckIn:output_type -> train.Receipt
+	9,  // 54: train.TicketService.Board:output_type -> train.Receipt
+	28, // 55: train.TicketService.GetSeatMap:output_type -> train.SeatMap
+	11, // 56: train.TicketService.UpgradeTicket:output_type -> train.UpgradeResponse
+	44, // [44:57] is the sub-list for method output_type
+	31, // [31:44] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_proto_ticketing_proto_init() }
@@ -612,7 +2581,7 @@ func file_proto_ticketing_proto_init() {
 			}
 		}
 		file_proto_ticketing_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Receipt); i {
+			switch v := v.(*SeatPreferences); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -624,7 +2593,7 @@ func file_proto_ticketing_proto_init() {
 			}
 		}
 		file_proto_ticketing_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*User); i {
+			switch v := v.(*Receipt); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -636,7 +2605,7 @@ func file_proto_ticketing_proto_init() {
 			}
 		}
 		file_proto_ticketing_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UserRequest); i {
+			switch v := v.(*UpgradeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -648,7 +2617,7 @@ func file_proto_ticketing_proto_init() {
 			}
 		}
 		file_proto_ticketing_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SectionRequest); i {
+			switch v := v.(*UpgradeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -660,7 +2629,7 @@ func file_proto_ticketing_proto_init() {
 			}
 		}
 		file_proto_ticketing_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SeatResponse); i {
+			switch v := v.(*StateChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -672,7 +2641,7 @@ func file_proto_ticketing_proto_init() {
 			}
 		}
 		file_proto_ticketing_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse); i {
+			switch v := v.(*User); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -684,6 +2653,66 @@ func file_proto_ticketing_proto_init() {
 			}
 		}
 		file_proto_ticketing_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SectionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookedSeat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ModifySeatRequest); i {
 			case 0:
 				return &v.state
@@ -695,19 +2724,152 @@ func file_proto_ticketing_proto_init() {
 				return nil
 			}
 		}
+		file_proto_ticketing_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatAssignment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketDocumentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TicketDocument); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BoardingPass); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateBoardingPassRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateBoardingPassResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatMapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatMap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_ticketing_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SeatAttributes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_ticketing_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      7,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_ticketing_proto_goTypes,
 		DependencyIndexes: file_proto_ticketing_proto_depIdxs,
+		EnumInfos:         file_proto_ticketing_proto_enumTypes,
 		MessageInfos:      file_proto_ticketing_proto_msgTypes,
 	}.Build()
 	File_proto_ticketing_proto = out.File
@@ -22,11 +22,37 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TicketServiceClient interface {
+	// Purchases a ticket and assigns the passenger a seat.
 	PurchaseTicket(ctx context.Context, in *PurchaseRequest, opts ...grpc.CallOption) (*Receipt, error)
+	// Returns the receipt for a passenger's ticket.
 	GetReceipt(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Receipt, error)
+	// Lists the tickets in a section, or in every section, ordered by seat.
+	// Results can be filtered by state and class and are returned a page at
+	// a time when page_size is set.
 	ViewSeats(ctx context.Context, in *SectionRequest, opts ...grpc.CallOption) (*SeatResponse, error)
+	// Removes a passenger from the train.
 	RemoveUser(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Moves a passenger to a different seat.
 	ModifySeat(ctx context.Context, in *ModifySeatRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Streams the occupancy of a section: a snapshot first, then an event for
+	// every seat taken or freed. A new snapshot is sent if the client falls
+	// too far behind.
+	WatchSeats(ctx context.Context, in *SectionRequest, opts ...grpc.CallOption) (TicketService_WatchSeatsClient, error)
+	// Renders a passenger's ticket as a printable PDF or HTML document.
+	GetTicketDocument(ctx context.Context, in *TicketDocumentRequest, opts ...grpc.CallOption) (*TicketDocument, error)
+	// Issues a signed boarding pass for a passenger's ticket.
+	GetBoardingPass(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*BoardingPass, error)
+	// Checks a boarding pass at the gate and records it as scanned.
+	ValidateBoardingPass(ctx context.Context, in *ValidateBoardingPassRequest, opts ...grpc.CallOption) (*ValidateBoardingPassResponse, error)
+	// Checks a passenger in for the journey.
+	CheckIn(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Receipt, error)
+	// Records that a checked-in passenger has boarded the train.
+	Board(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Receipt, error)
+	// Lists every seat on the train with its status and attributes.
+	GetSeatMap(ctx context.Context, in *SeatMapRequest, opts ...grpc.CallOption) (*SeatMap, error)
+	// Moves a passenger to a seat in a higher class, charging the difference
+	// in fares and freeing their previous seat.
+	UpgradeTicket(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error)
 }
 
 type ticketServiceClient struct {
@@ -82,15 +108,136 @@ func (c *ticketServiceClient) ModifySeat(ctx context.Context, in *ModifySeatRequ
 	return out, nil
 }
 
+func (c *ticketServiceClient) WatchSeats(ctx context.Context, in *SectionRequest, opts ...grpc.CallOption) (TicketService_WatchSeatsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TicketService_ServiceDesc.Streams[0], "/train.TicketService/WatchSeats", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ticketServiceWatchSeatsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TicketService_WatchSeatsClient interface {
+	Recv() (*SeatEvent, error)
+	grpc.ClientStream
+}
+
+type ticketServiceWatchSeatsClient struct {
+	grpc.ClientStream
+}
+
+func (x *ticketServiceWatchSeatsClient) Recv() (*SeatEvent, error) {
+	m := new(SeatEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ticketServiceClient) GetTicketDocument(ctx context.Context, in *TicketDocumentRequest, opts ...grpc.CallOption) (*TicketDocument, error) {
+	out := new(TicketDocument)
+	err := c.cc.Invoke(ctx, "/train.TicketService/GetTicketDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) GetBoardingPass(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*BoardingPass, error) {
+	out := new(BoardingPass)
+	err := c.cc.Invoke(ctx, "/train.TicketService/GetBoardingPass", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ValidateBoardingPass(ctx context.Context, in *ValidateBoardingPassRequest, opts ...grpc.CallOption) (*ValidateBoardingPassResponse, error) {
+	out := new(ValidateBoardingPassResponse)
+	err := c.cc.Invoke(ctx, "/train.TicketService/ValidateBoardingPass", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) CheckIn(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Receipt, error) {
+	out := new(Receipt)
+	err := c.cc.Invoke(ctx, "/train.TicketService/CheckIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) Board(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*Receipt, error) {
+	out := new(Receipt)
+	err := c.cc.Invoke(ctx, "/train.TicketService/Board", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) GetSeatMap(ctx context.Context, in *SeatMapRequest, opts ...grpc.CallOption) (*SeatMap, error) {
+	out := new(SeatMap)
+	err := c.cc.Invoke(ctx, "/train.TicketService/GetSeatMap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) UpgradeTicket(ctx context.Context, in *UpgradeRequest, opts ...grpc.CallOption) (*UpgradeResponse, error) {
+	out := new(UpgradeResponse)
+	err := c.cc.Invoke(ctx, "/train.TicketService/UpgradeTicket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TicketServiceServer is the server API for TicketService service.
 // All implementations must embed UnimplementedTicketServiceServer
 // for forward compatibility
 type TicketServiceServer interface {
+	// Purchases a ticket and assigns the passenger a seat.
 	PurchaseTicket(context.Context, *PurchaseRequest) (*Receipt, error)
+	// Returns the receipt for a passenger's ticket.
 	GetReceipt(context.Context, *UserRequest) (*Receipt, error)
+	// Lists the tickets in a section, or in every section, ordered by seat.
+	// Results can be filtered by state and class and are returned a page at
+	// a time when page_size is set.
 	ViewSeats(context.Context, *SectionRequest) (*SeatResponse, error)
+	// Removes a passenger from the train.
 	RemoveUser(context.Context, *UserRequest) (*StatusResponse, error)
+	// Moves a passenger to a different seat.
 	ModifySeat(context.Context, *ModifySeatRequest) (*StatusResponse, error)
+	// Streams the occupancy of a section: a snapshot first, then an event for
+	// every seat taken or freed. A new snapshot is sent if the client falls
+	// too far behind.
+	WatchSeats(*SectionRequest, TicketService_WatchSeatsServer) error
+	// Renders a passenger's ticket as a printable PDF or HTML document.
+	GetTicketDocument(context.Context, *TicketDocumentRequest) (*TicketDocument, error)
+	// Issues a signed boarding pass for a passenger's ticket.
+	GetBoardingPass(context.Context, *UserRequest) (*BoardingPass, error)
+	// Checks a boarding pass at the gate and records it as scanned.
+	ValidateBoardingPass(context.Context, *ValidateBoardingPassRequest) (*ValidateBoardingPassResponse, error)
+	// Checks a passenger in for the journey.
+	CheckIn(context.Context, *UserRequest) (*Receipt, error)
+	// Records that a checked-in passenger has boarded the train.
+	Board(context.Context, *UserRequest) (*Receipt, error)
+	// Lists every seat on the train with its status and attributes.
+	GetSeatMap(context.Context, *SeatMapRequest) (*SeatMap, error)
+	// Moves a passenger to a seat in a higher class, charging the difference
+	// in fares and freeing their previous seat.
+	UpgradeTicket(context.Context, *UpgradeRequest) (*UpgradeResponse, error)
 	mustEmbedUnimplementedTicketServiceServer()
 }
 
@@ -113,6 +260,30 @@ func (UnimplementedTicketServiceServer) RemoveUser(context.Context, *UserRequest
 func (UnimplementedTicketServiceServer) ModifySeat(context.Context, *ModifySeatRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ModifySeat not implemented")
 }
+func (UnimplementedTicketServiceServer) WatchSeats(*SectionRequest, TicketService_WatchSeatsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSeats not implemented")
+}
+func (UnimplementedTicketServiceServer) GetTicketDocument(context.Context, *TicketDocumentRequest) (*TicketDocument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTicketDocument not implemented")
+}
+func (UnimplementedTicketServiceServer) GetBoardingPass(context.Context, *UserRequest) (*BoardingPass, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoardingPass not implemented")
+}
+func (UnimplementedTicketServiceServer) ValidateBoardingPass(context.Context, *ValidateBoardingPassRequest) (*ValidateBoardingPassResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateBoardingPass not implemented")
+}
+func (UnimplementedTicketServiceServer) CheckIn(context.Context, *UserRequest) (*Receipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckIn not implemented")
+}
+func (UnimplementedTicketServiceServer) Board(context.Context, *UserRequest) (*Receipt, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Board not implemented")
+}
+func (UnimplementedTicketServiceServer) GetSeatMap(context.Context, *SeatMapRequest) (*SeatMap, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSeatMap not implemented")
+}
+func (UnimplementedTicketServiceServer) UpgradeTicket(context.Context, *UpgradeRequest) (*UpgradeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpgradeTicket not implemented")
+}
 func (UnimplementedTicketServiceServer) mustEmbedUnimplementedTicketServiceServer() {}
 
 // UnsafeTicketServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +387,153 @@ func _TicketService_ModifySeat_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketService_WatchSeats_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SectionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TicketServiceServer).WatchSeats(m, &ticketServiceWatchSeatsServer{stream})
+}
+
+type TicketService_WatchSeatsServer interface {
+	Send(*SeatEvent) error
+	grpc.ServerStream
+}
+
+type ticketServiceWatchSeatsServer struct {
+	grpc.ServerStream
+}
+
+func (x *ticketServiceWatchSeatsServer) Send(m *SeatEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TicketService_GetTicketDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TicketDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).GetTicketDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TicketService/GetTicketDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).GetTicketDocument(ctx, req.(*TicketDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_GetBoardingPass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).GetBoardingPass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TicketService/GetBoardingPass",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).GetBoardingPass(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ValidateBoardingPass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateBoardingPassRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ValidateBoardingPass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TicketService/ValidateBoardingPass",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ValidateBoardingPass(ctx, req.(*ValidateBoardingPassRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TicketService/CheckIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).CheckIn(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_Board_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).Board(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TicketService/Board",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).Board(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_GetSeatMap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeatMapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).GetSeatMap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TicketService/GetSeatMap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).GetSeatMap(ctx, req.(*SeatMapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_UpgradeTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpgradeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).UpgradeTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/train.TicketService/UpgradeTicket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).UpgradeTicket(ctx, req.(*UpgradeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TicketService_ServiceDesc is the grpc.ServiceDesc for TicketService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,7 +561,41 @@ var TicketService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ModifySeat",
 			Handler:    _TicketService_ModifySeat_Handler,
 		},
+		{
+			MethodName: "GetTicketDocument",
+			Handler:    _TicketService_GetTicketDocument_Handler,
+		},
+		{
+			MethodName: "GetBoardingPass",
+			Handler:    _TicketService_GetBoardingPass_Handler,
+		},
+		{
+			MethodName: "ValidateBoardingPass",
+			Handler:    _TicketService_ValidateBoardingPass_Handler,
+		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _TicketService_CheckIn_Handler,
+		},
+		{
+			MethodName: "Board",
+			Handler:    _TicketService_Board_Handler,
+		},
+		{
+			MethodName: "GetSeatMap",
+			Handler:    _TicketService_GetSeatMap_Handler,
+		},
+		{
+			MethodName: "UpgradeTicket",
+			Handler:    _TicketService_UpgradeTicket_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchSeats",
+			Handler:       _TicketService_WatchSeats_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/ticketing.proto",
 }
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	train "ticketing-svc/proto"
+)
+
+// WithWheelchairSpaces marks seats, written like "A-0", as wheelchair
+// spaces. Identifiers that do not parse are ignored.
+func WithWheelchairSpaces(labels ...string) Option {
+	return func(s *server) {
+		for _, label := range labels {
+			if seat, ok := parseSeat(label); ok {
+				s.wheelchair[seat] = true
+			}
+		}
+	}
+}
+
+// WithCompanionSeats marks seats, written like "A-1", as companion seats
+// for wheelchair users. Identifiers that do not parse are ignored.
+func WithCompanionSeats(labels ...string) Option {
+	return func(s *server) {
+		for _, label := range labels {
+			if seat, ok := parseSeat(label); ok {
+				s.companion[seat] = true
+			}
+		}
+	}
+}
+
+// WithAccessibleRelease opens wheelchair spaces and companion seats to
+// every passenger from t. Without it they stay protected.
+func WithAccessibleRelease(t time.Time) Option {
+	return func(s *server) {
+		s.accessibleRelease = t
+	}
+}
+
+// restrict takes the seats that do not suit r's accessibility needs out of
+// l. On trains with wheelchair spaces, wheelchair users only get those, and
+// companions get companion seats while any are free. Before the release
+// time, other passengers get neither. s.mu must be held.
+func (s *server) restrict(l *Layout, r Request) {
+	needs := func(need train.AccessibilityNeed) bool { return slices.Contains(r.Needs, need) }
+	companionFree := false
+	for seat := range s.companion {
+		companionFree = companionFree || l.Free(seat)
+	}
+	protected := s.accessibleRelease.IsZero() || time.Now().Before(s.accessibleRelease)
+
+	blocked := make(map[Seat]bool, len(l.Blocked))
+	for seat := range l.Blocked {
+		blocked[seat] = true
+	}
+	for _, section := range l.Sections {
+		for number := 0; number < l.SeatsPerSection; number++ {
+			seat := Seat{Section: section, Number: number}
+			switch {
+			case needs(train.AccessibilityNeed_WHEELCHAIR_SPACE) && len(s.wheelchair) > 0:
+				blocked[seat] = blocked[seat] || !s.wheelchair[seat]
+			case needs(train.AccessibilityNeed_COMPANION_SEAT) && companionFree:
+				blocked[seat] = blocked[seat] || !s.companion[seat]
+			case protected:
+				blocked[seat] = blocked[seat] || s.wheelchair[seat] || s.companion[seat]
+			}
+		}
+	}
+	l.Blocked = blocked
+}
+
+// ListAssistance lists the passengers needing assistance with the stations
+// where they board and alight.
+func (s *server) ListAssistance(ctx context.Context, in *train.AssistanceRequest) (*train.AssistanceList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := &train.AssistanceList{}
+	add := func(receipt *train.Receipt, station string, action train.Assistance_Action) {
+		if in.Station != "" && station != in.Station {
+			return
+		}
+		list.Passengers = append(list.Passengers, &train.Assistance{
+			User:             receipt.User,
+			Seat:             receipt.Seat,
+			BookingReference: receipt.BookingReference,
+			Station:          station,
+			Action:           action,
+		})
+	}
+	for _, receipt := range s.tickets {
+		if len(receipt.GetUser().GetAccessibilityNeeds()) == 0 {
+			continue
+		}
+		add(receipt, receipt.From, train.Assistance_BOARDING)
+		add(receipt, receipt.To, train.Assistance_ALIGHTING)
+	}
+
+	sort.Slice(list.Passengers, func(i, j int) bool {
+		a, b := list.Passengers[i], list.Passengers[j]
+		if a.Station != b.Station {
+			return a.Station < b.Station
+		}
+		return a.Seat < b.Seat
+	})
+	return list, nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_server_PurchaseTicket_accessibleSeats(t *testing.T) {
+	s := NewServer(WithSeatLayout([]string{"A"}, 4), WithWheelchairSpaces("A-0"), WithCompanionSeats("A-1"))
+	wheelchair := []train.AccessibilityNeed{train.AccessibilityNeed_WHEELCHAIR_SPACE}
+	companion := []train.AccessibilityNeed{train.AccessibilityNeed_COMPANION_SEAT}
+
+	// Purchases run in order against the same server.
+	tests := []struct {
+		name     string
+		needs    []train.AccessibilityNeed
+		seat     string
+		wantSeat string
+		wantCode codes.Code
+	}{
+		{name: "success - protected seats skipped", wantSeat: "A-2"},
+		{name: "fail - protected seat requested", seat: "A-0", wantCode: codes.FailedPrecondition},
+		{name: "success - wheelchair space", needs: wheelchair, wantSeat: "A-0"},
+		{name: "fail - no wheelchair space left", needs: wheelchair, wantCode: codes.ResourceExhausted},
+		{name: "success - companion seat", needs: companion, wantSeat: "A-1"},
+		{name: "success - companion seats taken", needs: companion, wantSeat: "A-3"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.PurchaseTicket(context.Background(), &train.PurchaseRequest{
+				User:          &train.User{Email: strconv.Itoa(i) + "@example.com", AccessibilityNeeds: tt.needs},
+				RequestedSeat: tt.seat,
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("server.PurchaseTicket() error = %v, want %v", err, tt.wantCode)
+			}
+			if err == nil && got.Seat != tt.wantSeat {
+				t.Errorf("server.PurchaseTicket() seat = %s, want %s", got.Seat, tt.wantSeat)
+			}
+		})
+	}
+
+	released := NewServer(WithSeatLayout([]string{"A"}, 4), WithWheelchairSpaces("A-0"),
+		WithAccessibleRelease(time.Now().Add(-time.Minute)))
+	got, err := released.PurchaseTicket(context.Background(), &train.PurchaseRequest{User: &train.User{Email: "john.doe@example.com"}})
+	if err != nil || got.Seat != "A-0" {
+		t.Errorf("server.PurchaseTicket() after release = %v, %v, want A-0", got.GetSeat(), err)
+	}
+}
+
+func Test_server_ModifySeat_accessibleSeats(t *testing.T) {
+	s := NewServer(WithSeatLayout([]string{"A"}, 6), WithWheelchairSpaces("A-0", "A-4"), WithCompanionSeats("A-1"))
+	wheelchair := []train.AccessibilityNeed{train.AccessibilityNeed_WHEELCHAIR_SPACE}
+	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "john.doe@example.com"}, RequestedSeat: "A-2"})
+	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "jane.doe@example.com", AccessibilityNeeds: wheelchair}})
+
+	tests := []struct {
+		name     string
+		email    string
+		seat     string
+		wantCode codes.Code
+	}{
+		{name: "fail - wheelchair space kept", email: "john.doe@example.com", seat: "A-4", wantCode: codes.FailedPrecondition},
+		{name: "fail - companion seat kept", email: "john.doe@example.com", seat: "A-1", wantCode: codes.FailedPrecondition},
+		{name: "fail - wheelchair user away from wheelchair spaces", email: "jane.doe@example.com", seat: "A-3", wantCode: codes.FailedPrecondition},
+		{name: "success - wheelchair user to another space", email: "jane.doe@example.com", seat: "A-4"},
+		{name: "success - unprotected seat", email: "john.doe@example.com", seat: "A-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ModifySeat(context.Background(), &train.ModifySeatRequest{Email: tt.email, NewSeat: tt.seat})
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("server.ModifySeat() error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+
+	protected := NewServer(WithSeatLayout([]string{"A"}, 4), WithWheelchairSpaces("A-0"))
+	released := NewServer(WithSeatLayout([]string{"A"}, 4), WithWheelchairSpaces("A-0"),
+		WithAccessibleRelease(time.Now().Add(-time.Minute)))
+	for srv, wantCode := range map[*server]codes.Code{protected: codes.FailedPrecondition, released: codes.OK} {
+		srv.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "john.doe@example.com"}, RequestedSeat: "A-2"})
+		_, err := srv.ModifySeat(context.Background(), &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "A-0"})
+		if status.Code(err) != wantCode {
+			t.Errorf("server.ModifySeat() onto a wheelchair space = %v, want %v", err, wantCode)
+		}
+	}
+}
+
+func Test_server_ListAssistance(t *testing.T) {
+	s := NewServer()
+	for _, in := range []*train.PurchaseRequest{
+		{From: "London", To: "Paris", RequestedSeat: "A-1", User: &train.User{Email: "john.doe@example.com",
+			AccessibilityNeeds: []train.AccessibilityNeed{train.AccessibilityNeed_WHEELCHAIR_SPACE}}},
+		{From: "Lille", To: "Paris", RequestedSeat: "A-0", User: &train.User{Email: "jane.doe@example.com",
+			AccessibilityNeeds: []train.AccessibilityNeed{train.AccessibilityNeed_BOARDING_ASSISTANCE}}},
+		{From: "London", To: "Lille", User: &train.User{Email: "jim.doe@example.com"}},
+	} {
+		if _, err := s.PurchaseTicket(context.TODO(), in); err != nil {
+			t.Fatalf("server.PurchaseTicket() error = %v", err)
+		}
+	}
+
+	type stop struct {
+		station, seat string
+		action        train.Assistance_Action
+	}
+	tests := []struct {
+		name    string
+		station string
+		want    []stop
+	}{
+		{
+			name: "success - every station",
+			want: []stop{
+				{"Lille", "A-0", train.Assistance_BOARDING},
+				{"London", "A-1", train.Assistance_BOARDING},
+				{"Paris", "A-0", train.Assistance_ALIGHTING},
+				{"Paris", "A-1", train.Assistance_ALIGHTING},
+			},
+		},
+		{
+			name:    "success - one station",
+			station: "Lille",
+			want:    []stop{{"Lille", "A-0", train.Assistance_BOARDING}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ListAssistance(context.Background(), &train.AssistanceRequest{Station: tt.station})
+			if err != nil {
+				t.Fatalf("server.ListAssistance() error = %v", err)
+			}
+			if len(got.Passengers) != len(tt.want) {
+				t.Fatalf("server.ListAssistance() = %v, want %v", got.Passengers, tt.want)
+			}
+			for i, p := range got.Passengers {
+				if (stop{p.Station, p.Seat, p.Action}) != tt.want[i] {
+					t.Errorf("server.ListAssistance()[%d] = %v, want %v", i, p, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"ticketing-svc/allocation"
+	train "ticketing-svc/proto"
+)
+
+// Allocator chooses the seat for a new ticket.
+type Allocator interface {
+	// Allocate returns a free seat in l for r, or false if there is none.
+	Allocate(l *Layout, r Request) (Seat, bool)
+}
+
+// AllocatorFunc adapts a function to the Allocator interface.
+type AllocatorFunc func(l *Layout, r Request) (Seat, bool)
+
+// Allocate calls f(l, r).
+func (f AllocatorFunc) Allocate(l *Layout, r Request) (Seat, bool) {
+	return f(l, r)
+}
+
+// Layout is the state of the train an Allocator chooses from. Seats in a
+// section are treated as a line in number order.
+type Layout struct {
+	Sections        []string
+	SeatsPerSection int
+	Taken           map[Seat]string // group of the passenger in each taken seat; "" for none
+	Blocked         map[Seat]bool
+	Attributes      func(Seat) SeatAttributes
+}
+
+// Free reports whether seat can be allocated.
+func (l *Layout) Free(seat Seat) bool {
+	_, taken := l.Taken[seat]
+	return !taken && !l.Blocked[seat] && seat.Number >= 0 && seat.Number < l.SeatsPerSection
+}
+
+// Request describes the passenger a seat is allocated for.
+type Request struct {
+	Preferences *train.SeatPreferences
+	Group       string
+	Needs       []train.AccessibilityNeed
+	Class       train.SeatClass // restricts the seats to one class when set
+}
+
+// DefaultAllocator is the strategy used unless WithAllocator says otherwise.
+const DefaultAllocator = allocation.Default
+
+// allocators maps strategy names to their implementations. Its keys must be
+// allocation.Strategies.
+var allocators = map[string]Allocator{
+	// Keep the sections equally full.
+	"balanced": AllocatorFunc(func(l *Layout, r Request) (Seat, bool) {
+		occupied := make(map[string]int)
+		for seat := range l.Taken {
+			occupied[seat.Section]++
+		}
+		return pick(l, r, func(seat Seat) int { return occupied[seat.Section] })
+	}),
+	// Fill the first section from its first seat, then the next.
+	"front-to-back": AllocatorFunc(func(l *Layout, r Request) (Seat, bool) {
+		return pick(l, r, func(Seat) int { return 0 })
+	}),
+	// Seat passengers as far as possible from each other.
+	"spacing": AllocatorFunc(func(l *Layout, r Request) (Seat, bool) {
+		return pick(l, r, func(seat Seat) int { return -l.distance(seat, nil) })
+	}),
+	// Seat a group next to its members, otherwise as for best-fit.
+	"cluster": AllocatorFunc(func(l *Layout, r Request) (Seat, bool) {
+		member := func(group string) bool { return r.Group != "" && group == r.Group }
+		return pick(l, r, func(seat Seat) int {
+			if d := l.distance(seat, member); d < l.SeatsPerSection {
+				return d - l.SeatsPerSection
+			}
+			return l.fit(seat)
+		})
+	}),
+	// Fill the smallest gaps first so long runs of free seats stay intact.
+	"best-fit": AllocatorFunc(func(l *Layout, r Request) (Seat, bool) {
+		return pick(l, r, l.fit)
+	}),
+}
+
+// AllocatorNames lists the available allocation strategies.
+func AllocatorNames() []string {
+	names := make([]string, 0, len(allocators))
+	for name := range allocators {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NewAllocator returns the allocation strategy called name.
+func NewAllocator(name string) (Allocator, error) {
+	a, ok := allocators[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown seat allocator %q", name)
+	}
+	return a, nil
+}
+
+// WithAllocator sets the strategy used to choose seats.
+func WithAllocator(a Allocator) Option {
+	return func(s *server) {
+		s.allocator = a
+	}
+}
+
+// pick returns the free seat meeting the most of r's preferences, breaking
+// ties by the lowest cost and then by section and seat order.
+func pick(l *Layout, r Request, cost func(Seat) int) (Seat, bool) {
+	var (
+		best      Seat
+		bestScore = -1
+		bestCost  int
+	)
+	for _, section := range l.Sections {
+		for number := 0; number < l.SeatsPerSection; number++ {
+			seat := Seat{Section: section, Number: number}
+			if !l.Free(seat) {
+				continue
+			}
+			_, score := honoured(r.Preferences, l.Attributes(seat))
+			if score < bestScore {
+				continue
+			}
+			if c := cost(seat); score > bestScore || c < bestCost {
+				best, bestScore, bestCost = seat, score, c
+			}
+		}
+	}
+	return best, bestScore >= 0
+}
+
+// distance returns how many seats separate seat from the nearest taken seat
+// in its section whose group satisfies match, or twice the section length
+// if there is none. A nil match accepts every taken seat.
+func (l *Layout) distance(seat Seat, match func(group string) bool) int {
+	nearest := 2 * l.SeatsPerSection
+	for other, group := range l.Taken {
+		if other.Section != seat.Section || (match != nil && !match(group)) {
+			continue
+		}
+		d := seat.Number - other.Number
+		if d < 0 {
+			d = -d
+		}
+		nearest = min(nearest, d)
+	}
+	return nearest
+}
+
+// fit ranks seat for best-fit allocation: seats in shorter runs of free
+// seats come first, and seats at either end of a run before those inside.
+func (l *Layout) fit(seat Seat) int {
+	start, end := seat.Number, seat.Number
+	for l.Free(Seat{Section: seat.Section, Number: start - 1}) {
+		start--
+	}
+	for l.Free(Seat{Section: seat.Section, Number: end + 1}) {
+		end++
+	}
+	cost := 2 * (end - start + 1)
+	if start != seat.Number && end != seat.Number {
+		cost++
+	}
+	return cost
+}
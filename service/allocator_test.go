@@ -0,0 +1,143 @@
+package service
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+
+	"ticketing-svc/allocation"
+)
+
+// The configuration checks strategy names against allocation.Strategies, so
+// it must list exactly the strategies implemented here.
+func TestAllocatorNames(t *testing.T) {
+	if got := AllocatorNames(); !slices.Equal(got, allocation.Strategies) {
+		t.Errorf("AllocatorNames() = %v, want allocation.Strategies %v", got, allocation.Strategies)
+	}
+	if _, err := NewAllocator(DefaultAllocator); err != nil {
+		t.Errorf("NewAllocator(DefaultAllocator) error = %v", err)
+	}
+}
+
+func TestAllocators(t *testing.T) {
+	layout := func() *Layout {
+		return &Layout{
+			Sections:        []string{"A", "B"},
+			SeatsPerSection: 8,
+			Taken:           map[Seat]string{{"A", 0}: "family", {"A", 1}: "", {"B", 4}: ""},
+			Blocked:         map[Seat]bool{},
+			Attributes:      func(Seat) SeatAttributes { return SeatAttributes{} },
+		}
+	}
+
+	tests := []struct {
+		name      string
+		allocator string
+		request   Request
+		want      Seat
+	}{
+		{name: "balanced - emptier section", allocator: "balanced", want: Seat{"B", 0}},
+		{name: "front-to-back - first free seat", allocator: "front-to-back", want: Seat{"A", 2}},
+		{name: "spacing - furthest from others", allocator: "spacing", want: Seat{"A", 7}},
+		{name: "cluster - next to the group", allocator: "cluster", request: Request{Group: "family"}, want: Seat{"A", 2}},
+		{name: "cluster - new group fits the smallest gap", allocator: "cluster", request: Request{Group: "friends"}, want: Seat{"B", 5}},
+		{name: "best-fit - end of the smallest gap", allocator: "best-fit", want: Seat{"B", 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAllocator(tt.allocator)
+			if err != nil {
+				t.Fatalf("NewAllocator() error = %v", err)
+			}
+			got, ok := a.Allocate(layout(), tt.request)
+			if !ok || got != tt.want {
+				t.Errorf("Allocate() = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewAllocator("random"); err == nil {
+		t.Error("NewAllocator() with an unknown name succeeded")
+	}
+}
+
+// simulate runs a day of sales on a four-section train: groups of one to
+// four passengers book, and one in three sales is a cancellation of an
+// earlier group. It returns the share of seats sold at the end, the share of
+// free seats with no free neighbour, and how many passengers were turned
+// away.
+func simulate(a Allocator, seed int64) (load, fragmentation float64, rejected int) {
+	rnd := rand.New(rand.NewSource(seed))
+	l := &Layout{
+		Sections:        []string{"A", "B", "C", "D"},
+		SeatsPerSection: 40,
+		Taken:           make(map[Seat]string),
+		Blocked:         make(map[Seat]bool),
+		Attributes:      func(Seat) SeatAttributes { return SeatAttributes{} },
+	}
+	var groups []string
+	for i := 0; i < 120; i++ {
+		if len(groups) > 0 && rnd.Intn(3) == 0 {
+			j := rnd.Intn(len(groups))
+			for seat, group := range l.Taken {
+				if group == groups[j] {
+					delete(l.Taken, seat)
+				}
+			}
+			groups = append(groups[:j], groups[j+1:]...)
+			continue
+		}
+		group := "g" + strconv.Itoa(i)
+		groups = append(groups, group)
+		for n := 1 + rnd.Intn(4); n > 0; n-- {
+			seat, ok := a.Allocate(l, Request{Group: group})
+			if !ok {
+				rejected++
+				continue
+			}
+			l.Taken[seat] = group
+		}
+	}
+
+	capacity := len(l.Sections) * l.SeatsPerSection
+	free, isolated := 0, 0
+	for _, section := range l.Sections {
+		for number := 0; number < l.SeatsPerSection; number++ {
+			if l.Free(Seat{section, number}) {
+				free++
+				if !l.Free(Seat{section, number - 1}) && !l.Free(Seat{section, number + 1}) {
+					isolated++
+				}
+			}
+		}
+	}
+	load = float64(capacity-free) / float64(capacity)
+	if free > 0 {
+		fragmentation = float64(isolated) / float64(free)
+	}
+	return load, fragmentation, rejected
+}
+
+// BenchmarkAllocators compares the strategies on the same simulated sales,
+// reporting load factor and fragmentation alongside the timing:
+//
+//	go test -run '^$' -bench Allocators ./service
+func BenchmarkAllocators(b *testing.B) {
+	for _, name := range AllocatorNames() {
+		a, _ := NewAllocator(name)
+		b.Run(name, func(b *testing.B) {
+			var load, fragmentation float64
+			var rejected int
+			for i := 0; i < b.N; i++ {
+				l, f, r := simulate(a, int64(i))
+				load += l
+				fragmentation += f
+				rejected += r
+			}
+			b.ReportMetric(load/float64(b.N), "load")
+			b.ReportMetric(fragmentation/float64(b.N), "fragmentation")
+			b.ReportMetric(float64(rejected)/float64(b.N), "rejected/op")
+		})
+	}
+}
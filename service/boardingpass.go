@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"slices"
+	"time"
+
+	"ticketing-svc/boardingpass"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// qrSize is the width and height in pixels of boarding pass QR codes.
+const qrSize = 320
+
+// WithBoardingPassKey sets the key boarding passes are signed with. Without
+// it a key is generated, and passes stop verifying when the server restarts.
+func WithBoardingPassKey(key ed25519.PrivateKey) Option {
+	return func(s *server) {
+		s.passKey = key
+	}
+}
+
+// GetBoardingPass issues a signed boarding pass for a passenger's ticket
+// that has yet to board.
+func (s *server) GetBoardingPass(ctx context.Context, in *train.UserRequest) (*train.BoardingPass, error) {
+	s.mu.Lock()
+	receipt, ok := s.tickets[in.Email]
+	if ok {
+		receipt = proto.Clone(receipt).(*train.Receipt)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ticket found for email: %s", in.Email)
+	}
+	if !slices.Contains(boardingStates, receipt.State) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is %s and cannot board", stateName(receipt.State))
+	}
+	if receipt.OverbookedSection != "" {
+		return nil, errOverbooked
+	}
+
+	code, err := boardingpass.Sign(s.passKey, boardingpass.FromReceipt(receipt))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "sign boarding pass: %v", err)
+	}
+	qr, err := boardingpass.QR(code, qrSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "encode boarding pass: %v", err)
+	}
+	return &train.BoardingPass{Code: code, QrPng: qr}, nil
+}
+
+// ValidateBoardingPass checks a boarding pass against the current bookings
+// and records it as scanned when it is valid.
+func (s *server) ValidateBoardingPass(ctx context.Context, in *train.ValidateBoardingPassRequest) (*train.ValidateBoardingPassResponse, error) {
+	pass, err := boardingpass.Verify(s.passKey.Public().(ed25519.PublicKey), in.Code)
+	if errors.Is(err, boardingpass.ErrTampered) {
+		return &train.ValidateBoardingPassResponse{Result: train.ValidateBoardingPassResponse_TAMPERED}, nil
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "verify boarding pass: %v", err)
+	}
+
+	resp := &train.ValidateBoardingPassResponse{
+		Ticket: &train.Receipt{
+			From:             pass.From,
+			To:               pass.To,
+			User:             &train.User{FirstName: pass.FirstName, LastName: pass.LastName, Email: pass.Email},
+			Seat:             pass.Seat,
+			BookingReference: pass.Reference,
+		},
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, ok := s.tickets[pass.Email]
+	switch {
+	case !ok || receipt.BookingReference != pass.Reference,
+		receipt.State == train.TicketState_CANCELLED, receipt.State == train.TicketState_REFUNDED:
+		resp.Result = train.ValidateBoardingPassResponse_CANCELLED
+	case receipt.Seat != pass.Seat:
+		resp.Result = train.ValidateBoardingPassResponse_SUPERSEDED
+	case !s.scanned[pass.Reference].IsZero():
+		resp.Result = train.ValidateBoardingPassResponse_ALREADY_SCANNED
+	case !slices.Contains(boardingStates, receipt.State):
+		resp.Ticket.State = receipt.State
+		resp.Result = train.ValidateBoardingPassResponse_NOT_BOARDABLE
+	default:
+		s.scanned[pass.Reference] = time.Now().UTC()
+		s.dirty = true
+		resp.Result = train.ValidateBoardingPassResponse_VALID
+	}
+	return resp, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WithSeatClasses sets the class of each section, keyed by section name.
+// Sections not listed are standard class.
+func WithSeatClasses(classes map[string]train.SeatClass) Option {
+	return func(s *server) {
+		s.classes = classes
+	}
+}
+
+// WithFares sets the fare charged for each class. Tickets in classes
+// without a fare cost the price given in the purchase request.
+func WithFares(fares map[train.SeatClass]float64) Option {
+	return func(s *server) {
+		s.fares = fares
+	}
+}
+
+// classOf returns the class of the seats in section.
+func (s *server) classOf(section string) train.SeatClass {
+	if class, ok := s.classes[section]; ok {
+		return class
+	}
+	return train.SeatClass_STANDARD
+}
+
+// classSections returns the sections of class in allocation order.
+func (s *server) classSections(class train.SeatClass) []string {
+	var sections []string
+	for _, section := range s.sections {
+		if s.classOf(section) == class {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// lowestClass returns the lowest class with seats on the train, used when a
+// purchase does not name one.
+func (s *server) lowestClass() train.SeatClass {
+	lowest := train.SeatClass_SEAT_CLASS_UNSPECIFIED
+	for _, section := range s.sections {
+		if class := s.classOf(section); lowest == train.SeatClass_SEAT_CLASS_UNSPECIFIED || class < lowest {
+			lowest = class
+		}
+	}
+	return lowest
+}
+
+// fare returns the price of a ticket in class, or price if the class has
+// no fare.
+func (s *server) fare(ctx context.Context, class train.SeatClass, price float64) float64 {
+	_, span := tracer.Start(ctx, "pricing")
+	defer span.End()
+
+	fare, ok := s.fares[class]
+	if !ok {
+		fare = price
+	}
+	span.SetAttributes(
+		attribute.String("ticket.class", className(class)),
+		attribute.Bool("ticket.class_fare", ok),
+		attribute.Float64("ticket.price", fare),
+	)
+	return fare
+}
+
+// className returns class in lower case for error messages, e.g. "first".
+func className(class train.SeatClass) string {
+	return strings.ToLower(class.String())
+}
+
+// UpgradeTicket moves a passenger to a seat in a higher class, charging the
+// difference in fares.
+func (s *server) UpgradeTicket(ctx context.Context, in *train.UpgradeRequest) (*train.UpgradeResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, ok := s.tickets[in.Email]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ticket found for email: %s", in.Email)
+	}
+	if len(s.classSections(in.Class)) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no %s class seats on this train", className(in.Class))
+	}
+	if in.Class <= receipt.Class {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is already %s class", className(receipt.Class))
+	}
+	if !slices.Contains(seatChangeStates, receipt.State) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is %s and cannot be upgraded", stateName(receipt.State))
+	}
+	if receipt.OverbookedSection != "" {
+		return nil, errOverbooked
+	}
+
+	oldSeat := s.seats[in.Email]
+	seat, _, err := s.assignSeat(ctx, in.Email, Request{
+		Group: receipt.Group,
+		Needs: receipt.GetUser().GetAccessibilityNeeds(),
+		Class: in.Class,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishSeat(train.SeatEvent_SEAT_FREED, oldSeat.Section, receipt.Seat, receipt.User)
+	s.publishSeat(train.SeatEvent_SEAT_TAKEN, seat.Section, seat.String(), receipt.User)
+
+	// Without fares for both classes there is no difference to charge.
+	charged := 0.0
+	oldFare, oldOK := s.fares[receipt.Class]
+	newFare, newOK := s.fares[in.Class]
+	if oldOK && newOK {
+		charged = max(0, newFare-oldFare)
+	}
+
+	previousSeat := receipt.Seat
+	receipt.Seat = seat.String()
+	receipt.Class = in.Class
+	receipt.PricePaid += charged
+	s.counters.revenue += charged
+	s.dirty = true
+	s.emit(events.SeatModified, receipt, previousSeat)
+
+	return &train.UpgradeResponse{Receipt: proto.Clone(receipt).(*train.Receipt), Charged: charged}, nil
+}
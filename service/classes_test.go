@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_server_UpgradeTicket(t *testing.T) {
+	s := NewServer(
+		WithSeatLayout([]string{"A", "B", "C"}, 2),
+		WithSeatClasses(map[string]train.SeatClass{"A": train.SeatClass_FIRST, "B": train.SeatClass_BUSINESS}),
+		WithFares(map[train.SeatClass]float64{train.SeatClass_FIRST: 80, train.SeatClass_BUSINESS: 50, train.SeatClass_STANDARD: 20}),
+	)
+	for _, in := range []*train.PurchaseRequest{
+		{User: &train.User{Email: "john.doe@example.com"}, PricePaid: 5},
+		{User: &train.User{Email: "jim.doe@example.com"}},
+		{User: &train.User{Email: "jane.doe@example.com"}, Class: train.SeatClass_FIRST},
+	} {
+		if _, err := s.PurchaseTicket(context.TODO(), in); err != nil {
+			t.Fatalf("server.PurchaseTicket() error = %v", err)
+		}
+	}
+	if r, _ := s.GetReceipt(context.TODO(), &train.UserRequest{Email: "john.doe@example.com"}); r.Seat != "C-0" || r.PricePaid != 20 {
+		t.Fatalf("server.PurchaseTicket() = %s at %v, want C-0 at the standard fare", r.Seat, r.PricePaid)
+	}
+
+	// Upgrades run in order against the same server.
+	tests := []struct {
+		name        string
+		email       string
+		class       train.SeatClass
+		wantSeat    string
+		wantCharged float64
+		wantPrice   float64
+		wantCode    codes.Code
+	}{
+		{name: "success - standard to business", email: "john.doe@example.com", class: train.SeatClass_BUSINESS, wantSeat: "B-0", wantCharged: 30, wantPrice: 50},
+		{name: "fail - downgrade", email: "john.doe@example.com", class: train.SeatClass_STANDARD, wantCode: codes.FailedPrecondition},
+		{name: "fail - already in class", email: "jane.doe@example.com", class: train.SeatClass_FIRST, wantCode: codes.FailedPrecondition},
+		{name: "success - business to first", email: "john.doe@example.com", class: train.SeatClass_FIRST, wantSeat: "A-1", wantCharged: 30, wantPrice: 80},
+		{name: "fail - class full", email: "jim.doe@example.com", class: train.SeatClass_FIRST, wantCode: codes.ResourceExhausted},
+		{name: "fail - no class", email: "jim.doe@example.com", wantCode: codes.InvalidArgument},
+		{name: "fail - no ticket", email: "test@example.com", class: train.SeatClass_FIRST, wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.UpgradeTicket(context.Background(), &train.UpgradeRequest{Email: tt.email, Class: tt.class})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("server.UpgradeTicket() error = %v, want %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got.Receipt.Seat != tt.wantSeat || got.Receipt.Class != tt.class || got.Charged != tt.wantCharged || got.Receipt.PricePaid != tt.wantPrice {
+				t.Errorf("server.UpgradeTicket() = %v, want %s in %v, charged %v, price %v", got, tt.wantSeat, tt.class, tt.wantCharged, tt.wantPrice)
+			}
+		})
+	}
+
+	// The seats given up are free again, but only within their class.
+	if _, err := s.ModifySeat(context.TODO(), &train.ModifySeatRequest{Email: "jim.doe@example.com", NewSeat: "B-0"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("server.ModifySeat() across classes error = %v, want %v", err, codes.FailedPrecondition)
+	}
+	if _, err := s.ModifySeat(context.TODO(), &train.ModifySeatRequest{Email: "jim.doe@example.com", NewSeat: "C-0"}); err != nil {
+		t.Errorf("server.ModifySeat() to the freed seat error = %v", err)
+	}
+}
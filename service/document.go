@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+
+	"ticketing-svc/document"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// referenceAlphabet leaves out characters that are easily confused on a
+// printed ticket, such as 0 and O or 1 and I.
+const referenceAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// newBookingReference returns a random 8-character booking reference.
+func newBookingReference() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	for i := range b {
+		// 256 is a multiple of the alphabet size, so every character is
+		// equally likely.
+		b[i] = referenceAlphabet[int(b[i])%len(referenceAlphabet)]
+	}
+	return string(b[:])
+}
+
+// GetTicketDocument renders a passenger's ticket as PDF or HTML.
+func (s *server) GetTicketDocument(ctx context.Context, in *train.TicketDocumentRequest) (*train.TicketDocument, error) {
+	s.mu.Lock()
+	receipt, ok := s.tickets[in.Email]
+	if ok {
+		receipt = proto.Clone(receipt).(*train.Receipt)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ticket found for email: %s", in.Email)
+	}
+
+	var doc *train.TicketDocument
+	var err error
+	switch in.Format {
+	case train.TicketDocumentRequest_FORMAT_UNSPECIFIED, train.TicketDocumentRequest_PDF:
+		doc = &train.TicketDocument{ContentType: "application/pdf", Filename: "ticket-" + receipt.BookingReference + ".pdf"}
+		doc.Content, err = s.documents.PDF(in.Brand, receipt)
+	case train.TicketDocumentRequest_HTML:
+		doc = &train.TicketDocument{ContentType: "text/html; charset=utf-8", Filename: "ticket-" + receipt.BookingReference + ".html"}
+		doc.Content, err = s.documents.HTML(in.Brand, receipt)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown document format %v", in.Format)
+	}
+
+	if errors.Is(err, document.ErrUnknownBrand) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "render ticket: %v", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxImportRows bounds the passengers in one ImportTickets document.
+const maxImportRows = 5000
+
+// importColumns sets the PurchaseRequest field named by each import column
+// from a cell, returning an error if the cell is not valid.
+var importColumns = map[string]func(in *train.PurchaseRequest, cell string) error{
+	"email":      func(in *train.PurchaseRequest, cell string) error { in.User.Email = cell; return nil },
+	"first_name": func(in *train.PurchaseRequest, cell string) error { in.User.FirstName = cell; return nil },
+	"last_name":  func(in *train.PurchaseRequest, cell string) error { in.User.LastName = cell; return nil },
+	"from":       func(in *train.PurchaseRequest, cell string) error { in.From = cell; return nil },
+	"to":         func(in *train.PurchaseRequest, cell string) error { in.To = cell; return nil },
+	"seat":       func(in *train.PurchaseRequest, cell string) error { in.RequestedSeat = cell; return nil },
+	"group":      func(in *train.PurchaseRequest, cell string) error { in.Group = cell; return nil },
+	"price_paid": func(in *train.PurchaseRequest, cell string) error {
+		if cell == "" {
+			return nil
+		}
+		price, err := strconv.ParseFloat(cell, 64)
+		if err != nil || price < 0 {
+			return fmt.Errorf("price_paid %q is not a non-negative number", cell)
+		}
+		in.PricePaid = price
+		return nil
+	},
+	"class": func(in *train.PurchaseRequest, cell string) error {
+		if cell == "" {
+			return nil
+		}
+		class, ok := train.SeatClass_value[strings.ToUpper(cell)]
+		if !ok || class == int32(train.SeatClass_SEAT_CLASS_UNSPECIFIED) {
+			return fmt.Errorf("unknown class %q", cell)
+		}
+		in.Class = train.SeatClass(class)
+		return nil
+	},
+}
+
+// chunkReader reads the content of the ImportChunks received from a
+// stream, remembering whether the first asked for a dry run.
+type chunkReader struct {
+	recv   func() (*train.ImportChunk, error)
+	buf    []byte
+	first  bool // whether the first chunk has been received
+	dryRun bool
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.recv()
+		if err != nil {
+			return 0, err
+		}
+		if !r.first {
+			r.first, r.dryRun = true, chunk.DryRun
+		}
+		r.buf = chunk.Content
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// importRow is one parsed row of an import document.
+type importRow struct {
+	result *train.ImportResult
+	in     *train.PurchaseRequest
+}
+
+// readImport parses an import document into rows, setting the error of the
+// rows that cannot be booked. Errors in the document as a whole are
+// returned as gRPC status errors.
+func readImport(r io.Reader) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, status.Error(codes.InvalidArgument, "import document is empty")
+	}
+	if err != nil {
+		return nil, importError(err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		// Spreadsheets often start their CSV exports with a byte order mark.
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := importColumns[name]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown import column %q", name)
+		}
+		if slices.Contains(columns[:i], name) {
+			return nil, status.Errorf(codes.InvalidArgument, "import column %q appears twice", name)
+		}
+		columns[i] = name
+	}
+	if !slices.Contains(columns, "email") {
+		return nil, status.Error(codes.InvalidArgument, "import document has no email column")
+	}
+
+	var rows []importRow
+	lines := make(map[string]int) // line of each email seen so far
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, importError(err)
+		}
+		if len(rows) == maxImportRows {
+			return nil, status.Errorf(codes.InvalidArgument, "import document has more than %d rows", maxImportRows)
+		}
+
+		line, _ := cr.FieldPos(0)
+		row := importRow{
+			result: &train.ImportResult{Line: int32(line)},
+			in:     &train.PurchaseRequest{User: &train.User{}},
+		}
+		rows = append(rows, row)
+		if len(record) != len(columns) {
+			row.result.Error = fmt.Sprintf("row has %d fields, want %d", len(record), len(columns))
+			continue
+		}
+		for i, cell := range record {
+			if err := importColumns[columns[i]](row.in, strings.TrimSpace(cell)); err != nil && row.result.Error == "" {
+				row.result.Error = err.Error()
+			}
+		}
+		email := row.in.User.Email
+		row.result.Email = email
+		err = checkEmail(email)
+		switch {
+		case row.result.Error != "":
+		case err != nil:
+			row.result.Error = err.Error()
+		case lines[email] != 0:
+			row.result.Error = fmt.Sprintf("email already on line %d", lines[email])
+		default:
+			lines[email] = line
+		}
+	}
+}
+
+// importError describes a malformed import document.
+func importError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return status.Errorf(codes.InvalidArgument, "invalid import document: %v", parseErr)
+	}
+	return err
+}
+
+// ImportTickets books a ticket for each valid row of a CSV document. The
+// rows are booked together under one lock, so a charter is seated without
+// other sales interleaving. A dry run books them the same way and then
+// undoes it before anyone can see the seats.
+func (s *server) ImportTickets(stream train.Agent_ImportTicketsServer) error {
+	if !s.beginPurchase() {
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	defer s.inflight.Done()
+
+	r := &chunkReader{recv: stream.Recv}
+	rows, err := readImport(r)
+	if err != nil {
+		return err
+	}
+
+	report := &train.ImportReport{DryRun: r.dryRun}
+	s.mu.Lock()
+	var booked []*train.ImportResult
+	for _, row := range rows {
+		report.Rows = append(report.Rows, row.result)
+		if row.result.Error != "" {
+			report.Failed++
+			continue
+		}
+		if _, ok := s.tickets[row.in.User.Email]; ok {
+			row.result.Error = "passenger already has a ticket"
+			report.Failed++
+			continue
+		}
+		receipt, err := s.purchase(stream.Context(), row.in)
+		if err != nil {
+			row.result.Error = status.Convert(err).Message()
+			report.Failed++
+			continue
+		}
+		booked = append(booked, row.result)
+		row.result.Receipt = receipt
+		report.Booked++
+	}
+	for _, result := range booked {
+		receipt := result.Receipt
+		if r.dryRun {
+			delete(s.tickets, receipt.User.Email)
+			delete(s.seats, receipt.User.Email)
+			receipt.BookingReference = ""
+			continue
+		}
+		s.record(receipt)
+		// The stored ticket may change once the lock is released.
+		result.Receipt = proto.Clone(receipt).(*train.Receipt)
+	}
+	s.mu.Unlock()
+
+	return stream.SendAndClose(report)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// importTickets streams document to client in chunks of three bytes.
+func importTickets(client train.AgentClient, document string, dryRun bool) (*train.ImportReport, error) {
+	stream, err := client.ImportTickets(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(document); i += 3 {
+		chunk := &train.ImportChunk{DryRun: dryRun && i == 0, Content: []byte(document[i:min(i+3, len(document))])}
+		if err := stream.Send(chunk); err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+func Test_server_ImportTickets(t *testing.T) {
+	const document = "\ufeffEmail,first_name,seat,class,price_paid\n" +
+		"ann@example.com,Ann,A-3,,20\n" +
+		"bob@example.com,\"Bob, Jr.\",,standard,\n" +
+		"cy@example.com,Cy,,,twenty\n" +
+		"ann@example.com,Ann,,,\n" +
+		"dee@example.com,Dee,A-3,,\n" +
+		"taken@example.com,Taken,,,\n" +
+		"eve@example.com,Eve,,first,\n" +
+		",Nobody,,,\n" +
+		"gus at example.com,Gus,,,\n" +
+		"fay@example.com,Fay\n"
+	wantErrors := []string{
+		"",
+		"",
+		`price_paid "twenty" is not a non-negative number`,
+		"email already on line 2",
+		"seat A-3 is not available",
+		"passenger already has a ticket",
+		"no first class seats on this train",
+		"user email is required",
+		`user email "gus at example.com" is not a valid address`,
+		"row has 2 fields, want 5",
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		s := NewServer()
+		client := train.NewAgentClient(dial(t, s))
+		s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "taken@example.com"}})
+
+		report, err := importTickets(client, document, dryRun)
+		if err != nil {
+			t.Fatalf("ImportTickets(dry run %v) error = %v", dryRun, err)
+		}
+		if report.DryRun != dryRun || report.Booked != 2 || report.Failed != 8 || len(report.Rows) != len(wantErrors) {
+			t.Fatalf("ImportTickets(dry run %v) = dry run %v, %d booked, %d failed, %d rows", dryRun, report.DryRun, report.Booked, report.Failed, len(report.Rows))
+		}
+		for i, row := range report.Rows {
+			if row.Line != int32(i+2) || row.Error != wantErrors[i] || (row.Receipt != nil) != (row.Error == "") {
+				t.Errorf("row %d = %v, want line %d with error %q", i, row, i+2, wantErrors[i])
+			}
+		}
+		if ann := report.Rows[0].Receipt; ann.Seat != "A-3" || ann.PricePaid != 20 || (ann.BookingReference == "") != dryRun {
+			t.Errorf("ann's receipt = %v", ann)
+		}
+
+		stats := s.Stats()
+		_, err = s.GetReceipt(context.TODO(), &train.UserRequest{Email: "bob@example.com"})
+		if dryRun && (status.Code(err) != codes.NotFound || stats.TicketsIssued != 1 || len(s.seats) != 1) {
+			t.Errorf("dry run booked tickets: %v, %d issued, %d seats", err, stats.TicketsIssued, len(s.seats))
+		}
+		if !dryRun && (err != nil || stats.TicketsIssued != 3) {
+			t.Errorf("import did not book tickets: %v, %d issued", err, stats.TicketsIssued)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		document string
+	}{
+		{name: "fail - empty document", document: ""},
+		{name: "fail - unknown column", document: "email,phone\n"},
+		{name: "fail - repeated column", document: "email,email\n"},
+		{name: "fail - no email column", document: "first_name\nAnn\n"},
+		{name: "fail - malformed CSV", document: "email\n\"ann@example.com\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := train.NewAgentClient(dial(t, NewServer()))
+			if _, err := importTickets(client, tt.document, false); status.Code(err) != codes.InvalidArgument {
+				t.Errorf("ImportTickets() error = %v, want %v", err, codes.InvalidArgument)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	train "ticketing-svc/proto"
+
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Restore replaces the server's booking state with the last snapshot saved
+// in its store. It must be called before the server starts serving.
+func (s *server) Restore(ctx context.Context) error {
+	_, span := tracer.Start(ctx, "storage.Load")
+	defer span.End()
+
+	snap, err := s.store.Load()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("restore bookings: %w", err)
+	}
+	if snap == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if snap.Tickets != nil {
+		s.tickets = snap.Tickets
+	}
+	if snap.Seats != nil {
+		s.seats = snap.Seats
+	}
+	if snap.Scanned != nil {
+		s.scanned = snap.Scanned
+	}
+	if snap.Cancelled != nil {
+		s.cancelled = snap.Cancelled
+	}
+	if s.outbox != nil {
+		s.outbox.Restore(snap.Outbox)
+	}
+
+	// Tickets saved before booking references existed are given one now.
+	for _, receipt := range s.tickets {
+		if receipt.BookingReference == "" {
+			receipt.BookingReference = newBookingReference()
+			s.dirty = true
+		}
+		if receipt.Class == train.SeatClass_SEAT_CLASS_UNSPECIFIED {
+			// Tickets saved before classes existed take their section's.
+			receipt.Class = s.classOf(s.seats[receipt.GetUser().GetEmail()].Section)
+			s.dirty = true
+		}
+		if receipt.State == train.TicketState_TICKET_STATE_UNSPECIFIED {
+			// Tickets saved before states were tracked had been paid for.
+			receipt.State = train.TicketState_PAID
+			s.dirty = true
+		}
+	}
+	return nil
+}
+
+// beginPurchase registers an in-flight purchase, or reports false once the
+// server is draining.
+func (s *server) beginPurchase() bool {
+	s.drainMu.Lock()
+	defer s.drainMu.Unlock()
+
+	if s.draining {
+		return false
+	}
+	s.inflight.Add(1)
+	return true
+}
+
+// Drain stops accepting purchases, ends every WatchSeats stream and waits
+// for purchases in progress to finish or for ctx to be done.
+func (s *server) Drain(ctx context.Context) error {
+	s.drainMu.Lock()
+	s.draining = true
+	s.drainMu.Unlock()
+
+	s.watchers.close()
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("drain purchases: %w", ctx.Err())
+	}
+}
+
+// Flush saves the booking state and pending events to the store if they
+// changed since the last flush.
+func (s *server) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty && (s.outbox == nil || !s.outbox.Unsaved()) {
+		return nil
+	}
+
+	_, span := tracer.Start(ctx, "storage.Save")
+	defer span.End()
+
+	snap := &Snapshot{
+		Tickets:   s.tickets,
+		Seats:     s.seats,
+		Scanned:   s.scanned,
+		Cancelled: s.cancelled,
+	}
+	var version uint64
+	if s.outbox != nil {
+		snap.Outbox, version = s.outbox.Checkpoint()
+	}
+	if err := s.store.Save(snap); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("flush bookings: %w", err)
+	}
+	s.dirty = false
+	if s.outbox != nil {
+		s.outbox.Saved(version)
+	}
+	return nil
+}
+
+// CheckStorage reports whether the booking store is reachable.
+func (s *server) CheckStorage(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "storage.Ping")
+	defer span.End()
+
+	err := s.store.Ping(ctx)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
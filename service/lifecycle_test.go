@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func Test_server_Drain(t *testing.T) {
+	s := NewServer()
+
+	if err := s.Drain(context.Background()); err != nil {
+		t.Fatalf("server.Drain() error = %v", err)
+	}
+
+	_, err := s.PurchaseTicket(context.Background(), &train.PurchaseRequest{
+		User: &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+	})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("server.PurchaseTicket() after Drain error = %v, want Unavailable", err)
+	}
+}
+
+func Test_server_FlushRestore(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "bookings.json"))
+
+	s := NewServer(WithStore(store))
+	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+		From:      "London",
+		To:        "France",
+		User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		PricePaid: 20.0,
+	})
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatalf("server.Flush() error = %v", err)
+	}
+
+	restored := NewServer(WithStore(store))
+	if err := restored.Restore(context.Background()); err != nil {
+		t.Fatalf("server.Restore() error = %v", err)
+	}
+
+	got, err := restored.GetReceipt(context.Background(), &train.UserRequest{Email: "john.doe@example.com"})
+	if err != nil {
+		t.Fatalf("server.GetReceipt() error = %v", err)
+	}
+	want := s.tickets["john.doe@example.com"]
+	if !proto.Equal(got, want) {
+		t.Errorf("restored receipt = %v, want %v", got, want)
+	}
+
+	// Restored seats are counted as taken, so the next purchase does not
+	// reuse them.
+	next, _ := restored.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+		User: &train.User{Email: "jane.doe@example.com"},
+	})
+	if next.Seat != "B-0" {
+		t.Errorf("seat after restore = %s, want B-0", next.Seat)
+	}
+}
+
+func Test_server_outbox(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "bookings.json"))
+	outbox := events.NewOutbox()
+	s := NewServer(WithStore(store), WithOutbox(outbox))
+
+	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+		User: &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+	})
+	s.ModifySeat(context.TODO(), &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "B-3"})
+	s.RemoveUser(context.TODO(), &train.UserRequest{Email: "john.doe@example.com"})
+	s.RemoveUser(context.TODO(), &train.UserRequest{Email: "nobody@example.com"})
+
+	var got []string
+	for _, entry := range outbox.Entries() {
+		got = append(got, fmt.Sprintf("%s %s %s", entry.Event.Type, entry.Event.PreviousSeat, entry.Event.Seat))
+	}
+	want := []string{
+		"ticket.purchased  A-0",
+		"seat.modified A-0 B-3",
+		"ticket.cancelled  B-3",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("outbox events = %q, want %q", got, want)
+	}
+
+	// Undelivered events survive a restart.
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatalf("server.Flush() error = %v", err)
+	}
+	restoredOutbox := events.NewOutbox()
+	restored := NewServer(WithStore(store), WithOutbox(restoredOutbox))
+	if err := restored.Restore(context.Background()); err != nil {
+		t.Fatalf("server.Restore() error = %v", err)
+	}
+	if got, want := restoredOutbox.Entries(), outbox.Entries(); !reflect.DeepEqual(got, want) {
+		t.Errorf("restored outbox = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"strings"
+
+	"ticketing-svc/document"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// manifestChunkSize is the most document bytes sent in one ManifestChunk.
+const manifestChunkSize = 32 << 10
+
+// chunkWriter sends what is written to it as ManifestChunks of at most
+// manifestChunkSize bytes. The first chunk carries the document's type and
+// file name.
+type chunkWriter struct {
+	send  func(*train.ManifestChunk) error
+	chunk *train.ManifestChunk // the chunk being filled
+	sent  bool                 // whether any chunk has been sent
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		room := manifestChunkSize - len(w.chunk.Content)
+		if room == 0 {
+			if err := w.Flush(); err != nil {
+				return n - len(p), err
+			}
+			continue
+		}
+		room = min(room, len(p))
+		w.chunk.Content = append(w.chunk.Content, p[:room]...)
+		p = p[room:]
+	}
+	return n, nil
+}
+
+// Flush sends the chunk being filled. The first chunk is sent even when
+// empty, so the receiver always learns the document type.
+func (w *chunkWriter) Flush() error {
+	if w.sent && len(w.chunk.Content) == 0 {
+		return nil
+	}
+	if err := w.send(w.chunk); err != nil {
+		return err
+	}
+	w.chunk, w.sent = &train.ManifestChunk{}, true
+	return nil
+}
+
+// ExportManifest streams the passenger list, in seat order, as a CSV,
+// JSON Lines or PDF document.
+func (s *server) ExportManifest(in *train.ManifestRequest, stream train.Agent_ExportManifestServer) error {
+	first := &train.ManifestChunk{}
+	switch in.Format {
+	case train.ManifestRequest_FORMAT_UNSPECIFIED, train.ManifestRequest_CSV:
+		first.ContentType, first.Filename = "text/csv; charset=utf-8", "manifest.csv"
+	case train.ManifestRequest_JSON_LINES:
+		first.ContentType, first.Filename = "application/jsonl", "manifest.jsonl"
+	case train.ManifestRequest_PDF:
+		first.ContentType, first.Filename = "application/pdf", "manifest.pdf"
+	default:
+		return status.Errorf(codes.InvalidArgument, "unknown manifest format %v", in.Format)
+	}
+
+	// Copy the passengers so the lock is not held while the document is
+	// rendered and sent.
+	s.mu.Lock()
+	var entries []document.ManifestEntry
+	for _, b := range s.bookings() {
+		user := b.receipt.GetUser()
+		entries = append(entries, document.ManifestEntry{
+			Name:    strings.TrimSpace(user.GetFirstName() + " " + user.GetLastName()),
+			Email:   user.GetEmail(),
+			Seat:    b.receipt.Seat,
+			Section: b.key.section,
+			Status:  b.receipt.State.String(),
+		})
+	}
+	s.mu.Unlock()
+
+	w := &chunkWriter{send: stream.Send, chunk: first}
+	var err error
+	switch in.Format {
+	case train.ManifestRequest_JSON_LINES:
+		err = document.ManifestJSONLines(w, entries)
+	case train.ManifestRequest_PDF:
+		err = s.documents.ManifestPDF(w, "passenger manifest", entries)
+	default:
+		err = document.ManifestCSV(w, entries)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil && status.Code(err) == codes.Unknown {
+		return status.Errorf(codes.Internal, "render manifest: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"ticketing-svc/document"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportManifest streams a manifest from client and returns its first
+// chunk, the whole document and the number of chunks.
+func exportManifest(client train.AgentClient, format train.ManifestRequest_Format) (*train.ManifestChunk, []byte, int, error) {
+	stream, err := client.ExportManifest(context.Background(), &train.ManifestRequest{Format: format})
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	var first *train.ManifestChunk
+	var content []byte
+	chunks := 0
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return first, content, chunks, nil
+		}
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		if first == nil {
+			first = chunk
+		}
+		content = append(content, chunk.Content...)
+		chunks++
+	}
+}
+
+func Test_server_ExportManifest(t *testing.T) {
+	s := NewServer()
+	client := train.NewAgentClient(dial(t, s))
+	for _, user := range []*train.User{
+		{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		{FirstName: "Jane", LastName: "Doe, Jr.", Email: "jane.doe@example.com"},
+	} {
+		s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: user})
+	}
+	s.CheckIn(context.TODO(), &train.UserRequest{Email: "jane.doe@example.com"})
+
+	tests := []struct {
+		name            string
+		format          train.ManifestRequest_Format
+		wantContentType string
+		wantContent     string // prefix of the document
+		wantCode        codes.Code
+	}{
+		{
+			name:            "success - CSV by default",
+			wantContentType: "text/csv; charset=utf-8",
+			wantContent:     "Name,Email,Seat,Section,Status\nJohn Doe,john.doe@example.com,A-0,A,PAID\n\"Jane Doe, Jr.\",jane.doe@example.com,B-0,B,CHECKED_IN\n",
+		},
+		{
+			name:            "success - JSON Lines",
+			format:          train.ManifestRequest_JSON_LINES,
+			wantContentType: "application/jsonl",
+			wantContent:     `{"name":"John Doe","email":"john.doe@example.com","seat":"A-0","section":"A","status":"PAID"}` + "\n",
+		},
+		{
+			name:            "success - PDF",
+			format:          train.ManifestRequest_PDF,
+			wantContentType: "application/pdf",
+			wantContent:     "%PDF-",
+		},
+		{
+			name:     "fail - unknown format",
+			format:   train.ManifestRequest_Format(99),
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, content, _, err := exportManifest(client, tt.format)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("ExportManifest() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if first.ContentType != tt.wantContentType || first.Filename == "" {
+				t.Errorf("first chunk = %q, %q, want content type %q and a file name", first.ContentType, first.Filename, tt.wantContentType)
+			}
+			if !bytes.HasPrefix(content, []byte(tt.wantContent)) {
+				t.Errorf("ExportManifest() = %q, want prefix %q", content, tt.wantContent)
+			}
+		})
+	}
+
+	t.Run("success - large train in chunks", func(t *testing.T) {
+		s := NewServer(WithSeatLayout([]string{"A"}, 800))
+		client := train.NewAgentClient(dial(t, s))
+		for i := 0; i < 800; i++ {
+			email := strings.Repeat("x", 20) + strconv.Itoa(i) + "@example.com"
+			s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: email}})
+		}
+
+		_, content, chunks, err := exportManifest(client, train.ManifestRequest_JSON_LINES)
+		if err != nil {
+			t.Fatalf("ExportManifest() error = %v", err)
+		}
+		if want := len(content)/manifestChunkSize + 1; chunks != want {
+			t.Errorf("ExportManifest() sent %d chunks for %d bytes, want %d", chunks, len(content), want)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+		if len(lines) != 800 {
+			t.Fatalf("ExportManifest() has %d lines, want 800", len(lines))
+		}
+		var last document.ManifestEntry
+		if err := json.Unmarshal([]byte(lines[799]), &last); err != nil || last.Seat != "A-799" {
+			t.Errorf("last line = %s (%v), want seat A-799", lines[799], err)
+		}
+	})
+}
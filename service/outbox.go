@@ -0,0 +1,26 @@
+package service
+
+import (
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+)
+
+// WithOutbox records booking lifecycle events in outbox. The pending events
+// are flushed to and restored from the store together with the bookings.
+func WithOutbox(outbox *events.Outbox) Option {
+	return func(s *server) {
+		s.outbox = outbox
+	}
+}
+
+// emit records an event of type t for the ticket in r. previousSeat is set
+// for SeatModified events. s.mu must be held so the event is part of the
+// same change as the booking it describes.
+func (s *server) emit(t events.Type, r *train.Receipt, previousSeat string) {
+	if s.outbox == nil {
+		return
+	}
+	e := events.FromReceipt(t, r)
+	e.PreviousSeat = previousSeat
+	s.outbox.Add(e)
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// errOverbooked is returned for seat operations on tickets that have no
+// seat because they were sold beyond capacity.
+var errOverbooked = status.Error(codes.FailedPrecondition, "ticket is overbooked and has no seat; an agent must resolve it")
+
+// WithOverbooking lets sections sell tickets beyond their seats, keyed by
+// section name, as a percentage of the seats in the section.
+func WithOverbooking(percent map[string]int) Option {
+	return func(s *server) {
+		s.overbooking = percent
+	}
+}
+
+// overbookLimit returns how many tickets section may sell beyond its seats.
+func (s *server) overbookLimit(section string) int {
+	return s.seatsPerSection * s.overbooking[section] / 100
+}
+
+// overbooked counts the tickets sold beyond capacity in each section.
+// s.mu must be held.
+func (s *server) overbooked() map[string]int {
+	counts := make(map[string]int)
+	for _, receipt := range s.tickets {
+		if receipt.OverbookedSection != "" {
+			counts[receipt.OverbookedSection]++
+		}
+	}
+	return counts
+}
+
+// overbookSection returns the section of class with the most overbooking
+// allowance left, or "" if none has any. s.mu must be held.
+func (s *server) overbookSection(class train.SeatClass) string {
+	counts := s.overbooked()
+	best, bestLeft := "", 0
+	for _, section := range s.classSections(class) {
+		if left := s.overbookLimit(section) - counts[section]; left > bestLeft {
+			best, bestLeft = section, left
+		}
+	}
+	return best
+}
+
+// ListOverbooked lists the tickets sold beyond capacity, oldest first.
+func (s *server) ListOverbooked(ctx context.Context, in *train.OverbookedRequest) (*train.OverbookedList, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := &train.OverbookedList{}
+	for _, receipt := range s.tickets {
+		if receipt.OverbookedSection != "" {
+			list.Tickets = append(list.Tickets, proto.Clone(receipt).(*train.Receipt))
+		}
+	}
+	sort.Slice(list.Tickets, func(i, j int) bool {
+		return purchased(list.Tickets[i]).Before(purchased(list.Tickets[j]))
+	})
+	return list, nil
+}
+
+// ResolveOverbooking settles an overbooked ticket by seating the passenger
+// in their class or a lower one, or by cancelling it with compensation.
+func (s *server) ResolveOverbooking(ctx context.Context, in *train.ResolveOverbookingRequest) (*train.ResolveOverbookingResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, ok := s.tickets[in.Email]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ticket found for email: %s", in.Email)
+	}
+	if receipt.OverbookedSection == "" {
+		return nil, status.Error(codes.FailedPrecondition, "ticket is not overbooked")
+	}
+
+	r := Request{Group: receipt.Group, Needs: receipt.GetUser().GetAccessibilityNeeds()}
+	resp := &train.ResolveOverbookingResponse{}
+	switch in.Resolution {
+	case train.ResolveOverbookingRequest_REASSIGN:
+		r.Class = receipt.Class
+		seat, _, err := s.assignSeat(ctx, in.Email, r)
+		if err != nil {
+			return nil, err
+		}
+		s.seatOverbooked(receipt, seat)
+
+	case train.ResolveOverbookingRequest_DOWNGRADE:
+		var err error = status.Errorf(codes.FailedPrecondition, "no class below %s", className(receipt.Class))
+		for r.Class = receipt.Class - 1; r.Class > train.SeatClass_SEAT_CLASS_UNSPECIFIED; r.Class-- {
+			if len(s.classSections(r.Class)) == 0 {
+				continue
+			}
+			var seat Seat
+			if seat, _, err = s.assignSeat(ctx, in.Email, r); err == nil {
+				oldFare, oldOK := s.fares[receipt.Class]
+				newFare, newOK := s.fares[r.Class]
+				if oldOK && newOK {
+					resp.Refunded = max(0, oldFare-newFare)
+				}
+				receipt.Class = r.Class
+				receipt.PricePaid -= resp.Refunded
+				s.counters.refunds += resp.Refunded
+				s.seatOverbooked(receipt, seat)
+				break
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+	case train.ResolveOverbookingRequest_COMPENSATE:
+		if in.Compensation < 0 {
+			return nil, status.Error(codes.InvalidArgument, "compensation cannot be negative")
+		}
+		if err := transition(receipt, train.TicketState_CANCELLED); err != nil {
+			return nil, err
+		}
+		receipt.OverbookedSection = ""
+		s.release(receipt)
+		s.counters.removals++
+		s.counters.compensation += in.Compensation
+		s.emit(events.TicketCancelled, receipt, "")
+		resp.Compensation = in.Compensation
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown resolution %v", in.Resolution)
+	}
+
+	s.dirty = true
+	resp.Receipt = proto.Clone(receipt).(*train.Receipt)
+	return resp, nil
+}
+
+// seatOverbooked gives an overbooked ticket the seat just assigned to it.
+// s.mu must be held.
+func (s *server) seatOverbooked(receipt *train.Receipt, seat Seat) {
+	receipt.Seat = seat.String()
+	receipt.OverbookedSection = ""
+	s.publishSeat(train.SeatEvent_SEAT_TAKEN, seat.Section, receipt.Seat, receipt.User)
+	s.emit(events.WaitlistPromoted, receipt, "")
+}
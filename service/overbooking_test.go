@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_server_overbooking(t *testing.T) {
+	outbox := events.NewOutbox()
+	s := NewServer(
+		WithOutbox(outbox),
+		WithSeatLayout([]string{"A", "B"}, 2),
+		WithSeatClasses(map[string]train.SeatClass{"A": train.SeatClass_FIRST}),
+		WithFares(map[train.SeatClass]float64{train.SeatClass_FIRST: 80, train.SeatClass_STANDARD: 20}),
+		WithOverbooking(map[string]int{"A": 50, "B": 50}),
+	)
+	purchase := func(email string, class train.SeatClass) func() error {
+		return func() error {
+			_, err := s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: email}, Class: class})
+			return err
+		}
+	}
+	remove := func(email string) func() error {
+		return func() error {
+			_, err := s.RemoveUser(context.TODO(), &train.UserRequest{Email: email})
+			return err
+		}
+	}
+	checkIn := func(email string) func() error {
+		return func() error {
+			_, err := s.CheckIn(context.TODO(), &train.UserRequest{Email: email})
+			return err
+		}
+	}
+	resolve := func(email string, resolution train.ResolveOverbookingRequest_Resolution, wantSeat string, wantPrice float64) func() error {
+		return func() error {
+			got, err := s.ResolveOverbooking(context.TODO(), &train.ResolveOverbookingRequest{Email: email, Resolution: resolution, Compensation: 50})
+			if err == nil && (got.Receipt.Seat != wantSeat || got.Receipt.PricePaid != wantPrice || got.Receipt.OverbookedSection != "") {
+				t.Errorf("server.ResolveOverbooking() = %v, want seat %q at %v", got.Receipt, wantSeat, wantPrice)
+			}
+			return err
+		}
+	}
+	listed := func(section string, want ...string) func() error {
+		return func() error {
+			got, err := s.ViewSeats(context.TODO(), &train.SectionRequest{Section: section})
+			var emails []string
+			for _, seat := range got.GetSeats() {
+				emails = append(emails, seat.User.Email)
+			}
+			if !slices.Equal(emails, want) {
+				t.Errorf("server.ViewSeats() = %v, want %v", emails, want)
+			}
+			return err
+		}
+	}
+	std, first := train.SeatClass_STANDARD, train.SeatClass_FIRST
+
+	// Steps run in order against the same server.
+	tests := []struct {
+		name     string
+		step     func() error
+		wantCode codes.Code
+	}{
+		{name: "success - seated", step: purchase("a@example.com", std)},
+		{name: "success - last seat", step: purchase("b@example.com", std)},
+		{name: "success - overbooked", step: purchase("c@example.com", std)},
+		{name: "success - overbooked ticket listed last", step: listed("B", "a@example.com", "b@example.com", "c@example.com")},
+		{name: "fail - overbooking limit reached", step: purchase("d@example.com", std), wantCode: codes.ResourceExhausted},
+		{name: "fail - overbooked ticket checks in", step: checkIn("c@example.com"), wantCode: codes.FailedPrecondition},
+		{name: "fail - resolve seated ticket", step: resolve("a@example.com", train.ResolveOverbookingRequest_REASSIGN, "", 0), wantCode: codes.FailedPrecondition},
+		{name: "fail - reassign without free seat", step: resolve("c@example.com", train.ResolveOverbookingRequest_REASSIGN, "", 0), wantCode: codes.ResourceExhausted},
+		{name: "fail - no lower class", step: resolve("c@example.com", train.ResolveOverbookingRequest_DOWNGRADE, "", 0), wantCode: codes.FailedPrecondition},
+		{name: "success - seat freed", step: remove("a@example.com")},
+		{name: "success - reassign", step: resolve("c@example.com", train.ResolveOverbookingRequest_REASSIGN, "B-0", 20)},
+		{name: "success - first class full", step: purchase("e@example.com", first)},
+		{name: "success - last first class seat", step: purchase("f@example.com", first)},
+		{name: "success - first class overbooked", step: purchase("g@example.com", first)},
+		{name: "success - standard seat freed", step: remove("b@example.com")},
+		{name: "success - downgrade refunds the difference", step: resolve("g@example.com", train.ResolveOverbookingRequest_DOWNGRADE, "B-1", 20)},
+		{name: "success - standard overbooked again", step: purchase("h@example.com", std)},
+		{name: "success - compensate", step: resolve("h@example.com", train.ResolveOverbookingRequest_COMPENSATE, "", 20)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.step(); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+
+	list, _ := s.ListOverbooked(context.Background(), &train.OverbookedRequest{})
+	if len(list.Tickets) != 0 {
+		t.Errorf("server.ListOverbooked() = %v, want none", list.Tickets)
+	}
+	if _, err := s.GetReceipt(context.Background(), &train.UserRequest{Email: "h@example.com"}); status.Code(err) != codes.NotFound {
+		t.Errorf("compensated ticket still held: %v", err)
+	}
+	if stats := s.Stats(); stats.Compensation != 50 || stats.Revenue != 20*4+80*3 || stats.Refunds != 60 {
+		t.Errorf("server.Stats() compensation = %v, revenue = %v, refunds = %v", stats.Compensation, stats.Revenue, stats.Refunds)
+	}
+	var promoted []string
+	for _, entry := range outbox.Entries() {
+		if entry.Event.Type == events.WaitlistPromoted {
+			promoted = append(promoted, entry.Event.Email+" "+entry.Event.Seat)
+		}
+	}
+	if want := []string{"c@example.com B-0", "g@example.com B-1"}; !slices.Equal(promoted, want) {
+		t.Errorf("%s events = %v, want %v", events.WaitlistPromoted, promoted, want)
+	}
+}
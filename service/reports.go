@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"time"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ratio returns n divided by d, or 0 when d is 0.
+func ratio(n, d float64) float64 {
+	if d == 0 {
+		return 0
+	}
+	return n / d
+}
+
+// checkJourney rejects reports on a named journey. The service runs a
+// single train, so its reports cover every ticket it holds.
+func checkJourney(in *train.ReportRequest) error {
+	if in.Journey != "" {
+		return status.Errorf(codes.InvalidArgument, "reports cover the whole train; journey %q cannot be selected", in.Journey)
+	}
+	return nil
+}
+
+// wasCancelled reports whether receipt was cancelled at some point, even if
+// it has since been refunded.
+func wasCancelled(receipt *train.Receipt) bool {
+	if receipt.State == train.TicketState_CANCELLED {
+		return true
+	}
+	for _, change := range receipt.History {
+		if change.State == train.TicketState_CANCELLED {
+			return true
+		}
+	}
+	return false
+}
+
+// GetOccupancyReport returns the load factor of each section and of the
+// journey.
+func (s *server) GetOccupancyReport(ctx context.Context, in *train.ReportRequest) (*train.OccupancyReport, error) {
+	if err := checkJourney(in); err != nil {
+		return nil, err
+	}
+	stats := s.Stats()
+
+	report := &train.OccupancyReport{}
+	for _, name := range s.sections {
+		section := stats.Sections[name]
+		seats := section.Sold + section.Free
+		report.Sections = append(report.Sections, &train.SectionOccupancy{
+			Section:    name,
+			Class:      s.classOf(name),
+			Seats:      int32(seats),
+			Sold:       int32(section.Sold),
+			Overbooked: int32(section.Overbooked),
+			LoadFactor: ratio(float64(section.Sold), float64(seats)),
+		})
+		report.Seats += int32(seats)
+		report.Sold += int32(section.Sold)
+		report.Overbooked += int32(section.Overbooked)
+	}
+	report.LoadFactor = ratio(float64(report.Sold), float64(report.Seats))
+	return report, nil
+}
+
+// bucketStart returns the start of the period of length bucket holding t,
+// in UTC.
+func bucketStart(t time.Time, bucket train.ReportRequest_Bucket) time.Time {
+	t = t.UTC()
+	if bucket == train.ReportRequest_HOUR {
+		return t.Truncate(time.Hour)
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// GetSalesReport returns revenue and fares from every ticket issued, held
+// or cancelled, the lifetime activity counts, and ticket sales by hour or
+// day.
+func (s *server) GetSalesReport(ctx context.Context, in *train.ReportRequest) (*train.SalesReport, error) {
+	if _, ok := train.ReportRequest_Bucket_name[int32(in.Bucket)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown bucket %v", in.Bucket)
+	}
+	if err := checkJourney(in); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report := &train.SalesReport{
+		Tickets:      int32(len(s.tickets)),
+		SeatChanges:  int32(s.counters.seatModifications),
+		Compensation: s.counters.compensation,
+	}
+	// Cancelled and refunded tickets are kept with the tickets held, so the
+	// figures survive a restart. A cancelled ticket keeps its fare until it
+	// is refunded.
+	issued := make([]*train.Receipt, 0, len(s.tickets)+len(s.cancelled))
+	for _, receipt := range s.tickets {
+		issued = append(issued, receipt)
+	}
+	for _, receipt := range s.cancelled {
+		issued = append(issued, receipt)
+	}
+	var paid float64
+	buckets := make(map[time.Time]*train.SalesBucket)
+	for _, receipt := range issued {
+		report.TicketsIssued++
+		if wasCancelled(receipt) {
+			report.Cancellations++
+		}
+		paid += receipt.PricePaid
+		revenue := receipt.PricePaid
+		if receipt.State == train.TicketState_REFUNDED {
+			revenue = 0
+		}
+		report.Revenue += revenue
+
+		// Tickets saved before their history was kept have no purchase
+		// time to count them under.
+		bought := purchased(receipt)
+		if bought.IsZero() {
+			continue
+		}
+		start := bucketStart(bought, in.Bucket)
+		b, ok := buckets[start]
+		if !ok {
+			b = &train.SalesBucket{Start: timestamppb.New(start)}
+			buckets[start] = b
+			report.Sales = append(report.Sales, b)
+		}
+		b.Tickets++
+		b.Revenue += revenue
+	}
+	sort.Slice(report.Sales, func(i, j int) bool {
+		return report.Sales[i].Start.AsTime().Before(report.Sales[j].Start.AsTime())
+	})
+	report.AverageFare = ratio(paid, float64(report.TicketsIssued))
+	report.CancellationRate = ratio(float64(report.Cancellations), float64(report.TicketsIssued))
+	return report, nil
+}
+
+// ExportReport renders the occupancy or sales report as CSV.
+func (s *server) ExportReport(ctx context.Context, in *train.ExportReportRequest) (*train.ReportDocument, error) {
+	request := in.GetRequest()
+	if request == nil {
+		request = &train.ReportRequest{}
+	}
+	float := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+	integer := func(v int32) string { return strconv.Itoa(int(v)) }
+
+	var rows [][]string
+	var filename string
+	switch in.Report {
+	case train.ExportReportRequest_OCCUPANCY:
+		report, err := s.GetOccupancyReport(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		filename = "occupancy.csv"
+		rows = append(rows, []string{"section", "class", "seats", "sold", "overbooked", "load_factor"})
+		for _, section := range report.Sections {
+			rows = append(rows, []string{section.Section, className(section.Class), integer(section.Seats), integer(section.Sold), integer(section.Overbooked), float(section.LoadFactor)})
+		}
+		rows = append(rows, []string{"total", "", integer(report.Seats), integer(report.Sold), integer(report.Overbooked), float(report.LoadFactor)})
+
+	case train.ExportReportRequest_SALES:
+		report, err := s.GetSalesReport(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		filename = "sales.csv"
+		rows = [][]string{
+			{"metric", "value"},
+			{"tickets", integer(report.Tickets)},
+			{"revenue", float(report.Revenue)},
+			{"average_fare", float(report.AverageFare)},
+			{"tickets_issued", integer(report.TicketsIssued)},
+			{"cancellations", integer(report.Cancellations)},
+			{"cancellation_rate", float(report.CancellationRate)},
+			{"seat_changes", integer(report.SeatChanges)},
+			{"compensation", float(report.Compensation)},
+		}
+
+	case train.ExportReportRequest_SALES_OVER_TIME:
+		report, err := s.GetSalesReport(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		filename = "sales-over-time.csv"
+		rows = append(rows, []string{"start", "tickets", "revenue"})
+		for _, b := range report.Sales {
+			rows = append(rows, []string{b.Start.AsTime().Format(time.RFC3339), integer(b.Tickets), float(b.Revenue)})
+		}
+
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown report %v", in.Report)
+	}
+
+	var out bytes.Buffer
+	w := csv.NewWriter(&out)
+	if err := w.WriteAll(rows); err != nil {
+		return nil, status.Errorf(codes.Internal, "render report: %v", err)
+	}
+	return &train.ReportDocument{ContentType: "text/csv; charset=utf-8", Content: out.Bytes(), Filename: filename}, nil
+}
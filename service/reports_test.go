@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func Test_server_reports(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "bookings.json"))
+	opts := []Option{
+		WithSeatLayout([]string{"A", "B"}, 4),
+		WithSeatClasses(map[string]train.SeatClass{"B": train.SeatClass_BUSINESS}),
+		WithBlockedSeats("B-3"),
+		WithStore(store),
+	}
+	s := NewServer(opts...)
+	ctx := context.TODO()
+	bought := map[string]time.Time{
+		"a@example.com": time.Date(2026, 5, 1, 9, 15, 0, 0, time.UTC),
+		"b@example.com": time.Date(2026, 5, 1, 10, 45, 0, 0, time.UTC),
+		"c@example.com": time.Date(2026, 5, 1, 11, 0, 0, 0, time.UTC),
+		"d@example.com": time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC),
+	}
+	for _, in := range []*train.PurchaseRequest{
+		{User: &train.User{Email: "a@example.com"}, PricePaid: 10},
+		{User: &train.User{Email: "b@example.com"}, PricePaid: 20},
+		{User: &train.User{Email: "c@example.com"}, PricePaid: 30},
+		{User: &train.User{Email: "d@example.com"}, PricePaid: 40, Class: train.SeatClass_BUSINESS},
+	} {
+		receipt, err := s.PurchaseTicket(ctx, in)
+		if err != nil {
+			t.Fatalf("server.PurchaseTicket() error = %v", err)
+		}
+		s.tickets[receipt.User.Email].History[0].Time = timestamppb.New(bought[in.User.Email])
+	}
+	s.ModifySeat(ctx, &train.ModifySeatRequest{Email: "a@example.com", NewSeat: "A-3"})
+	s.RemoveUser(ctx, &train.UserRequest{Email: "c@example.com"})
+
+	t.Run("occupancy", func(t *testing.T) {
+		got, err := s.GetOccupancyReport(ctx, &train.ReportRequest{})
+		if err != nil {
+			t.Fatalf("server.GetOccupancyReport() error = %v", err)
+		}
+		want := &train.OccupancyReport{
+			Sections: []*train.SectionOccupancy{
+				{Section: "A", Class: train.SeatClass_STANDARD, Seats: 4, Sold: 2, LoadFactor: 0.5},
+				{Section: "B", Class: train.SeatClass_BUSINESS, Seats: 3, Sold: 1, LoadFactor: 1.0 / 3},
+			},
+			Seats:      7,
+			Sold:       3,
+			LoadFactor: 3.0 / 7,
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("server.GetOccupancyReport() = %v, want %v", got, want)
+		}
+		if _, err := s.GetOccupancyReport(ctx, &train.ReportRequest{Journey: "LON-PAR"}); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("server.GetOccupancyReport() for a journey error = %v, want %v", err, codes.InvalidArgument)
+		}
+	})
+
+	t.Run("sales", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			journey   string
+			bucket    train.ReportRequest_Bucket
+			wantSales []*train.SalesBucket
+			wantCode  codes.Code
+		}{
+			{
+				name: "success - by day",
+				wantSales: []*train.SalesBucket{
+					{Start: timestamppb.New(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)), Tickets: 3, Revenue: 60},
+					{Start: timestamppb.New(time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)), Tickets: 1, Revenue: 40},
+				},
+			},
+			{
+				name:   "success - by hour",
+				bucket: train.ReportRequest_HOUR,
+				wantSales: []*train.SalesBucket{
+					{Start: timestamppb.New(time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)), Tickets: 1, Revenue: 10},
+					{Start: timestamppb.New(time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)), Tickets: 1, Revenue: 20},
+					{Start: timestamppb.New(time.Date(2026, 5, 1, 11, 0, 0, 0, time.UTC)), Tickets: 1, Revenue: 30},
+					{Start: timestamppb.New(time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)), Tickets: 1, Revenue: 40},
+				},
+			},
+			{
+				name:     "fail - unknown bucket",
+				bucket:   train.ReportRequest_Bucket(9),
+				wantCode: codes.InvalidArgument,
+			},
+			{
+				name:     "fail - journey selected",
+				journey:  "LON-PAR",
+				wantCode: codes.InvalidArgument,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := s.GetSalesReport(ctx, &train.ReportRequest{Journey: tt.journey, Bucket: tt.bucket})
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("server.GetSalesReport() error = %v, want %v", err, tt.wantCode)
+				}
+				if err != nil {
+					return
+				}
+				want := &train.SalesReport{
+					Tickets:          3,
+					Revenue:          100,
+					AverageFare:      25,
+					TicketsIssued:    4,
+					Cancellations:    1,
+					CancellationRate: 0.25,
+					SeatChanges:      1,
+					Sales:            tt.wantSales,
+				}
+				if !proto.Equal(got, want) {
+					t.Errorf("server.GetSalesReport() = %v, want %v", got, want)
+				}
+			})
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			in       *train.ExportReportRequest
+			want     string
+			wantCode codes.Code
+		}{
+			{
+				name: "success - occupancy",
+				in:   &train.ExportReportRequest{Report: train.ExportReportRequest_OCCUPANCY},
+				want: "section,class,seats,sold,overbooked,load_factor\n" +
+					"A,standard,4,2,0,0.5\n" +
+					"B,business,3,1,0,0.3333333333333333\n" +
+					"total,,7,3,0,0.42857142857142855\n",
+			},
+			{
+				name: "success - sales",
+				in:   &train.ExportReportRequest{Report: train.ExportReportRequest_SALES},
+				want: "metric,value\ntickets,3\nrevenue,100\naverage_fare,25\ntickets_issued,4\n" +
+					"cancellations,1\ncancellation_rate,0.25\nseat_changes,1\ncompensation,0\n",
+			},
+			{
+				name: "success - sales over time",
+				in: &train.ExportReportRequest{
+					Report:  train.ExportReportRequest_SALES_OVER_TIME,
+					Request: &train.ReportRequest{Bucket: train.ReportRequest_DAY},
+				},
+				want: "start,tickets,revenue\n2026-05-01T00:00:00Z,3,60\n2026-05-02T00:00:00Z,1,40\n",
+			},
+			{
+				name:     "fail - unknown report",
+				in:       &train.ExportReportRequest{},
+				wantCode: codes.InvalidArgument,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := s.ExportReport(ctx, tt.in)
+				if status.Code(err) != tt.wantCode {
+					t.Fatalf("server.ExportReport() error = %v, want %v", err, tt.wantCode)
+				}
+				if err == nil && (string(got.Content) != tt.want || got.ContentType != "text/csv; charset=utf-8") {
+					t.Errorf("server.ExportReport() = %s\n%s, want\n%s", got.ContentType, got.Content, tt.want)
+				}
+			})
+		}
+	})
+
+	// Sales are counted from the tickets saved, so they survive a restart.
+	// A refund takes the fare out of the revenue.
+	t.Run("restart", func(t *testing.T) {
+		var ref string
+		for _, receipt := range s.cancelled {
+			ref = receipt.BookingReference
+		}
+		if _, err := s.RefundTicket(ctx, &train.RefundRequest{BookingReference: ref}); err != nil {
+			t.Fatalf("server.RefundTicket() error = %v", err)
+		}
+		if err := s.Flush(ctx); err != nil {
+			t.Fatalf("server.Flush() error = %v", err)
+		}
+		restored := NewServer(opts...)
+		if err := restored.Restore(ctx); err != nil {
+			t.Fatalf("server.Restore() error = %v", err)
+		}
+		got, err := restored.GetSalesReport(ctx, &train.ReportRequest{})
+		if err != nil {
+			t.Fatalf("server.GetSalesReport() error = %v", err)
+		}
+		want := &train.SalesReport{
+			Tickets:          3,
+			Revenue:          70,
+			AverageFare:      25,
+			TicketsIssued:    4,
+			Cancellations:    1,
+			CancellationRate: 0.25,
+			Sales: []*train.SalesBucket{
+				{Start: timestamppb.New(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)), Tickets: 3, Revenue: 30},
+				{Start: timestamppb.New(time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC)), Tickets: 1, Revenue: 40},
+			},
+		}
+		if !proto.Equal(got, want) {
+			t.Errorf("server.GetSalesReport() after restart = %v, want %v", got, want)
+		}
+	})
+
+	if empty, _ := NewServer().GetSalesReport(ctx, &train.ReportRequest{}); empty.AverageFare != 0 || empty.CancellationRate != 0 {
+		t.Errorf("empty sales report = %v, want zero ratios", empty)
+	}
+}
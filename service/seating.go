@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	train "ticketing-svc/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seatsPerRow is the number of seats across a carriage: window, aisle,
+// aisle, window.
+const seatsPerRow = 4
+
+// SeatAttributes describes where a seat is in its carriage.
+type SeatAttributes struct {
+	Window        bool
+	Aisle         bool
+	ForwardFacing bool
+	Quiet         bool
+	Wheelchair    bool // a wheelchair space
+	Companion     bool // kept for companions of wheelchair users
+}
+
+// WithQuietSections marks sections as quiet coaches.
+func WithQuietSections(sections ...string) Option {
+	return func(s *server) {
+		s.quietSections = sections
+	}
+}
+
+// WithBlockedSeats takes seats, written like "A-3", out of sale.
+// Identifiers that do not parse are ignored.
+func WithBlockedSeats(labels ...string) Option {
+	return func(s *server) {
+		for _, label := range labels {
+			if seat, ok := parseSeat(label); ok {
+				s.blocked[seat] = true
+			}
+		}
+	}
+}
+
+// attributes returns the attributes of seat. Seats are numbered row by
+// row, and rows alternate between facing forward and backward around
+// shared tables, starting with a forward-facing row.
+func (s *server) attributes(seat Seat) SeatAttributes {
+	column := seat.Number % seatsPerRow
+	return SeatAttributes{
+		Window:        column == 0 || column == seatsPerRow-1,
+		Aisle:         column == 1 || column == 2,
+		ForwardFacing: (seat.Number/seatsPerRow)%2 == 0,
+		Quiet:         slices.Contains(s.quietSections, seat.Section),
+		Wheelchair:    s.wheelchair[seat],
+		Companion:     s.companion[seat],
+	}
+}
+
+// honoured returns the preferences in prefs that attrs satisfies and how
+// many there are.
+func honoured(prefs *train.SeatPreferences, attrs SeatAttributes) (*train.SeatPreferences, int) {
+	met := &train.SeatPreferences{
+		Window:        prefs.GetWindow() && attrs.Window,
+		Aisle:         prefs.GetAisle() && attrs.Aisle,
+		ForwardFacing: prefs.GetForwardFacing() && attrs.ForwardFacing,
+		QuietCoach:    prefs.GetQuietCoach() && attrs.Quiet,
+	}
+	score := 0
+	for _, ok := range []bool{met.Window, met.Aisle, met.ForwardFacing, met.QuietCoach} {
+		if ok {
+			score++
+		}
+	}
+	return met, score
+}
+
+// assignSeat assigns a free seat to a user, chosen by the server's
+// Allocator, and returns the preferences in r the seat satisfies.
+// s.mu must be held.
+func (s *server) assignSeat(ctx context.Context, email string, r Request) (Seat, *train.SeatPreferences, error) {
+	_, span := tracer.Start(ctx, "assignSeat")
+	defer span.End()
+
+	seat, ok := s.allocator.Allocate(s.layout(r), r)
+	if !ok {
+		err := status.Error(codes.ResourceExhausted, "no more seats available")
+		span.SetStatus(otelcodes.Error, err.Error())
+		return Seat{}, nil, err
+	}
+
+	met, score := honoured(r.Preferences, s.attributes(seat))
+	span.SetAttributes(
+		attribute.String("seat.section", seat.Section),
+		attribute.Int("seat.number", seat.Number),
+		attribute.Int("seat.preferences_met", score),
+	)
+
+	// store the seat assignment
+	s.seats[email] = seat
+	return seat, met, nil
+}
+
+// layout describes the current state of the train for the Allocator,
+// leaving out seats that do not suit r. s.mu must be held.
+func (s *server) layout(r Request) *Layout {
+	sections := s.sections
+	if r.Class != train.SeatClass_SEAT_CLASS_UNSPECIFIED {
+		sections = s.classSections(r.Class)
+	}
+	l := &Layout{
+		Sections:        sections,
+		SeatsPerSection: s.seatsPerSection,
+		Taken:           make(map[Seat]string, len(s.seats)),
+		Blocked:         s.blocked,
+		Attributes:      s.attributes,
+	}
+	for email, seat := range s.seats {
+		l.Taken[seat] = s.tickets[email].GetGroup()
+	}
+	s.restrict(l, r)
+	return l
+}
+
+// requestSeat assigns the seat named label to a user, failing with
+// AlreadyExists if it is not free and FailedPrecondition if it does not
+// suit r's accessibility needs. s.mu must be held.
+func (s *server) requestSeat(email, label string, r Request) (Seat, error) {
+	seat, ok := parseSeat(label)
+	if !ok || !s.onTrain(seat) {
+		return Seat{}, status.Errorf(codes.InvalidArgument, "no seat %q on this train", label)
+	}
+	if r.Class != train.SeatClass_SEAT_CLASS_UNSPECIFIED && s.classOf(seat.Section) != r.Class {
+		return Seat{}, status.Errorf(codes.InvalidArgument, "seat %s is not %s class", seat, className(r.Class))
+	}
+	if _, ok := s.holders()[seat]; ok || s.blocked[seat] {
+		return Seat{}, status.Errorf(codes.AlreadyExists, "seat %s is not available", seat)
+	}
+	if !s.layout(r).Free(seat) {
+		return Seat{}, status.Errorf(codes.FailedPrecondition, "seat %s is kept for passengers with other accessibility needs", seat)
+	}
+	s.seats[email] = seat
+	return seat, nil
+}
+
+// onTrain reports whether seat is part of the train's layout.
+func (s *server) onTrain(seat Seat) bool {
+	return slices.Contains(s.sections, seat.Section) && seat.Number >= 0 && seat.Number < s.seatsPerSection
+}
+
+// holders returns the email of the passenger holding each taken seat.
+// s.mu must be held.
+func (s *server) holders() map[Seat]string {
+	taken := make(map[Seat]string, len(s.seats))
+	for email, seat := range s.seats {
+		taken[seat] = email
+	}
+	return taken
+}
+
+// seatStatus returns whether seat can be booked, given the holders of taken
+// seats. s.mu must be held.
+func (s *server) seatStatus(seat Seat, taken map[Seat]string) train.SeatStatus {
+	if s.blocked[seat] {
+		return train.SeatStatus_BLOCKED
+	}
+	email, ok := taken[seat]
+	if !ok {
+		return train.SeatStatus_FREE
+	}
+	if s.tickets[email].GetState() == train.TicketState_RESERVED {
+		return train.SeatStatus_HELD
+	}
+	return train.SeatStatus_SOLD
+}
+
+// GetSeatMap lists every seat on the train with its status and attributes.
+func (s *server) GetSeatMap(ctx context.Context, in *train.SeatMapRequest) (*train.SeatMap, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	taken := s.holders()
+	seatMap := &train.SeatMap{}
+	for _, section := range s.sections {
+		for number := 0; number < s.seatsPerSection; number++ {
+			seat := Seat{Section: section, Number: number}
+			attrs := s.attributes(seat)
+			seatMap.Seats = append(seatMap.Seats, &train.SeatInfo{
+				Seat:    seat.String(),
+				Section: section,
+				Class:   s.classOf(section),
+				Status:  s.seatStatus(seat, taken),
+				Attributes: &train.SeatAttributes{
+					Window:          attrs.Window,
+					Aisle:           attrs.Aisle,
+					ForwardFacing:   attrs.ForwardFacing,
+					QuietCoach:      attrs.Quiet,
+					WheelchairSpace: attrs.Wheelchair,
+					CompanionSeat:   attrs.Companion,
+				},
+			})
+		}
+	}
+	return seatMap, nil
+}
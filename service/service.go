@@ -2,61 +2,230 @@ package service
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
-	"log"
+	"net/mail"
+	"slices"
 	"sync"
+	"time"
 
+	"ticketing-svc/document"
+	"ticketing-svc/events"
 	train "ticketing-svc/proto"
+
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
-// This example assumes each section (A and B) has 10 seats each.
+var tracer = otel.Tracer("ticketing-svc/service")
+
+// The default layout has two sections (A and B) with 10 seats each.
 const seatsPerSection = 10
 
+var defaultSections = []string{"A", "B"}
+
 // Seat represents a seat on the train.
 type Seat struct {
-	Section string
-	Number  int
+	Section string `json:"section"`
+	Number  int    `json:"number"`
 }
 
 // server is used to implement train.TicketServiceServer.
 type server struct {
 	train.UnimplementedTicketServiceServer
-	mu        sync.Mutex // protects the following fields
-	tickets   map[string]*train.Receipt
-	seats     map[string]Seat
-	nextSeatA int // Next available seat number in Section A
-	nextSeatB int // Next available seat number in Section B
+	train.UnimplementedAgentServer
+	train.UnimplementedReportsServer
+	sections          []string // section names in allocation order
+	seatsPerSection   int
+	quietSections     []string      // sections that are quiet coaches
+	blocked           map[Seat]bool // seats taken out of sale
+	allocator         Allocator
+	wheelchair        map[Seat]bool              // wheelchair spaces
+	companion         map[Seat]bool              // companion seats beside them
+	accessibleRelease time.Time                  // when protected seats open to everyone; zero for never
+	classes           map[string]train.SeatClass // class of each section; standard if absent
+	fares             map[train.SeatClass]float64
+	overbooking       map[string]int // percentage of seats each section may sell beyond capacity
+	store             Store
+	documents         *document.Renderer
+	passKey           ed25519.PrivateKey // signs boarding passes
+
+	drainMu  sync.Mutex     // protects draining
+	draining bool           // set once shutdown begins; new purchases are refused
+	inflight sync.WaitGroup // purchases in progress
+	watchers *broadcaster   // WatchSeats subscriptions
+	outbox   *events.Outbox // booking lifecycle events; nil disables them
+
+	mu      sync.Mutex // protects the following fields
+	tickets map[string]*train.Receipt
+	seats   map[string]Seat
+	scanned map[string]time.Time // when boarding passes were scanned, by booking reference
+	// cancelled keeps cancelled and refunded tickets by booking reference,
+	// so they can still be refunded and are counted in reports.
+	cancelled map[string]*train.Receipt
+	dirty     bool     // state changed since the last Flush
+	counters  counters // lifetime activity since the process started
+}
+
+// Option configures a server created by NewServer.
+type Option func(*server)
+
+// WithSeatLayout sets the train sections and the number of seats in each.
+func WithSeatLayout(sections []string, seatsPerSection int) Option {
+	return func(s *server) {
+		s.sections = sections
+		s.seatsPerSection = seatsPerSection
+	}
+}
+
+// WithStore sets where booking state is restored from and flushed to.
+func WithStore(store Store) Option {
+	return func(s *server) {
+		s.store = store
+	}
+}
+
+// WithDocuments sets the renderer used for printable tickets.
+func WithDocuments(r *document.Renderer) Option {
+	return func(s *server) {
+		s.documents = r
+	}
 }
 
 // NewServer creates a TicketService server with an initialized in-memory store.
-func NewServer() *server {
-	return &server{
-		mu:      sync.Mutex{},
-		tickets: make(map[string]*train.Receipt),
-		seats:   make(map[string]Seat),
+func NewServer(opts ...Option) *server {
+	s := &server{
+		sections:        defaultSections,
+		seatsPerSection: seatsPerSection,
+		store:           memoryStore{},
+		documents:       document.Default(),
+		blocked:         make(map[Seat]bool),
+		allocator:       allocators[DefaultAllocator],
+		wheelchair:      make(map[Seat]bool),
+		companion:       make(map[Seat]bool),
+		watchers:        newBroadcaster(),
+		mu:              sync.Mutex{},
+		tickets:         make(map[string]*train.Receipt),
+		seats:           make(map[string]Seat),
+		scanned:         make(map[string]time.Time),
+		cancelled:       make(map[string]*train.Receipt),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.passKey == nil {
+		_, s.passKey, _ = ed25519.GenerateKey(nil)
+	}
+	return s
 }
 
 // PurchaseTicket creates a ticket purchase entry.
 func (s *server) PurchaseTicket(ctx context.Context, in *train.PurchaseRequest) (*train.Receipt, error) {
+	if !s.beginPurchase() {
+		return nil, status.Error(codes.Unavailable, "server is shutting down")
+	}
+	defer s.inflight.Done()
+
+	if err := checkEmail(in.GetUser().GetEmail()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	receipt, err := s.purchase(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	s.record(receipt)
+	return proto.Clone(receipt).(*train.Receipt), nil
+}
+
+// checkEmail returns why email cannot hold a ticket, or nil. Receipts and
+// notifications are sent to it, so it must be a bare mail address.
+func checkEmail(email string) error {
+	if email == "" {
+		return errors.New("user email is required")
+	}
+	if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+		return fmt.Errorf("user email %q is not a valid address", email)
+	}
+	return nil
+}
+
+// purchase assigns a seat for in and stores the paid ticket, without the
+// events and counters that record adds. s.mu must be held.
+func (s *server) purchase(ctx context.Context, in *train.PurchaseRequest) (*train.Receipt, error) {
 	// assign a seat
-	seat := s.assignSeat(in.User.Email)
+	var (
+		seat       Seat
+		met        *train.SeatPreferences
+		overbooked bool
+		err        error
+	)
+	r := Request{Preferences: in.Preferences, Group: in.Group, Needs: in.User.AccessibilityNeeds, Class: in.Class}
+	if r.Class != train.SeatClass_SEAT_CLASS_UNSPECIFIED && len(s.classSections(r.Class)) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no %s class seats on this train", className(r.Class))
+	}
+	if in.RequestedSeat != "" {
+		seat, err = s.requestSeat(in.User.Email, in.RequestedSeat, r)
+	} else {
+		if r.Class == train.SeatClass_SEAT_CLASS_UNSPECIFIED {
+			r.Class = s.lowestClass()
+		}
+		seat, met, err = s.assignSeat(ctx, in.User.Email, r)
+		if status.Code(err) == codes.ResourceExhausted {
+			// Sell beyond capacity where overbooking allows; the ticket
+			// gets a seat when an agent resolves it.
+			if section := s.overbookSection(r.Class); section != "" {
+				seat, overbooked, err = Seat{Section: section}, true, nil
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	receipt := &train.Receipt{
-		From:      in.From,
-		To:        in.To,
-		User:      in.User,
-		PricePaid: in.PricePaid,
-		Seat:      fmt.Sprintf("%s-%d", seat.Section, seat.Number),
+		From:             in.From,
+		To:               in.To,
+		User:             in.User,
+		PricePaid:        s.fare(ctx, s.classOf(seat.Section), in.PricePaid),
+		Seat:             seat.String(),
+		BookingReference: newBookingReference(),
+		Group:            in.Group,
+		Class:            s.classOf(seat.Section),
 	}
+	if in.Preferences != nil && in.RequestedSeat == "" {
+		receipt.HonouredPreferences = met
+	}
+	if overbooked {
+		receipt.Seat = ""
+		receipt.OverbookedSection = seat.Section
+	}
+	// Payment is taken with the purchase, so the reservation is paid at once.
+	transition(receipt, train.TicketState_RESERVED)
+	transition(receipt, train.TicketState_PAID)
 	s.tickets[in.User.Email] = receipt
-
 	return receipt, nil
 }
 
+// record announces a ticket stored by purchase and counts the sale.
+// s.mu must be held.
+func (s *server) record(receipt *train.Receipt) {
+	s.dirty = true
+	s.emit(events.TicketPurchased, receipt, "")
+	if receipt.OverbookedSection == "" {
+		seat := s.seats[receipt.User.Email]
+		s.publishSeat(train.SeatEvent_SEAT_TAKEN, seat.Section, receipt.Seat, receipt.User)
+	}
+	s.counters.ticketsIssued++
+	s.counters.revenue += receipt.PricePaid
+}
+
 // GetReceipt retrieves the receipt details for a user.
 func (s *server) GetReceipt(ctx context.Context, in *train.UserRequest) (*train.Receipt, error) {
 	s.mu.Lock()
@@ -64,49 +233,21 @@ func (s *server) GetReceipt(ctx context.Context, in *train.UserRequest) (*train.
 
 	receipt, ok := s.tickets[in.Email]
 	if !ok {
-		return nil, fmt.Errorf("no ticket found for email: %s", in.Email)
+		return nil, status.Errorf(codes.NotFound, "no ticket found for email: %s", in.Email)
 	}
-	return receipt, nil
+	return proto.Clone(receipt).(*train.Receipt), nil
 }
 
-// assignSeat assigns a seat to a user, alternating between sections A and B.
-func (s *server) assignSeat(email string) Seat {
-	var section string
-	var number int
-
-	// Assign to Section A or B depending on availability.
-	if s.nextSeatA <= s.nextSeatB && s.nextSeatA <= seatsPerSection {
-		section = "A"
-		number = s.nextSeatA
-		s.nextSeatA++
-	} else if s.nextSeatB <= seatsPerSection {
-		section = "B"
-		number = s.nextSeatB
-		s.nextSeatB++
-	} else {
-		log.Fatalf("No more seats available.")
-	}
-
-	// store the seat assignment
-	s.seats[email] = Seat{Section: section, Number: number}
-
-	return Seat{Section: section, Number: number}
-}
-
-// ViewSeats lists all the users in a requested section.
-func (s *server) ViewSeats(ctx context.Context, in *train.SectionRequest) (*train.SeatResponse, error) {
+// Receipts returns a copy of every ticket currently held.
+func (s *server) Receipts() []*train.Receipt {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	var usersInRequestedSection []*train.User
-	for email, receipt := range s.tickets {
-		seat, ok := s.seats[email]
-		if ok && seat.Section == in.Section {
-			usersInRequestedSection = append(usersInRequestedSection, receipt.User)
-		}
+	receipts := make([]*train.Receipt, 0, len(s.tickets))
+	for _, receipt := range s.tickets {
+		receipts = append(receipts, proto.Clone(receipt).(*train.Receipt))
 	}
-
-	return &train.SeatResponse{Users: usersInRequestedSection}, nil
+	return receipts
 }
 
 // RemoveUser removes a user from the train.
@@ -114,7 +255,20 @@ func (s *server) RemoveUser(ctx context.Context, in *train.UserRequest) (*train.
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if receipt, ok := s.tickets[in.Email]; ok {
+		if err := transition(receipt, train.TicketState_CANCELLED); err != nil {
+			return nil, err
+		}
+		s.counters.removals++
+		s.emit(events.TicketCancelled, receipt, "")
+		if seat, ok := s.seats[in.Email]; ok {
+			s.publishSeat(train.SeatEvent_SEAT_FREED, seat.Section, receipt.Seat, receipt.User)
+		}
+		s.release(receipt)
+	}
 	delete(s.tickets, in.Email)
+	delete(s.seats, in.Email)
+	s.dirty = true
 
 	return &train.StatusResponse{Message: "User removed successfully"}, nil
 }
@@ -126,9 +280,38 @@ func (s *server) ModifySeat(ctx context.Context, in *train.ModifySeatRequest) (*
 
 	receipt, ok := s.tickets[in.Email]
 	if !ok {
-		return nil, fmt.Errorf("no ticket found for email: %s", in.Email)
+		return nil, status.Errorf(codes.NotFound, "no ticket found for email: %s", in.Email)
+	}
+	if !slices.Contains(seatChangeStates, receipt.State) {
+		return nil, status.Errorf(codes.FailedPrecondition, "ticket is %s and its seat cannot be changed", stateName(receipt.State))
+	}
+	if receipt.OverbookedSection != "" {
+		return nil, errOverbooked
+	}
+
+	newSeat, ok := parseSeat(in.NewSeat)
+	if !ok || !s.onTrain(newSeat) {
+		return nil, status.Errorf(codes.InvalidArgument, "no seat %q on this train", in.NewSeat)
+	}
+	oldSeat := s.seats[in.Email]
+	if s.classOf(newSeat.Section) != s.classOf(oldSeat.Section) {
+		return nil, status.Errorf(codes.FailedPrecondition, "seat %s is %s class; use UpgradeTicket to change class", newSeat, className(s.classOf(newSeat.Section)))
+	}
+	if holder, ok := s.holders()[newSeat]; (ok && holder != in.Email) || s.blocked[newSeat] {
+		return nil, status.Errorf(codes.AlreadyExists, "seat %s is not available", newSeat)
+	}
+	r := Request{Needs: receipt.GetUser().GetAccessibilityNeeds()}
+	if newSeat != oldSeat && !s.layout(r).Free(newSeat) {
+		return nil, status.Errorf(codes.FailedPrecondition, "seat %s is kept for passengers with other accessibility needs", newSeat)
 	}
+	s.seats[in.Email] = newSeat
+	s.publishSeat(train.SeatEvent_SEAT_FREED, oldSeat.Section, receipt.Seat, receipt.User)
+	s.publishSeat(train.SeatEvent_SEAT_TAKEN, newSeat.Section, newSeat.String(), receipt.User)
 
-	receipt.Seat = in.NewSeat
+	previousSeat := receipt.Seat
+	receipt.Seat = newSeat.String()
+	s.dirty = true
+	s.emit(events.SeatModified, receipt, previousSeat)
+	s.counters.seatModifications++
 	return &train.StatusResponse{Message: "Seat modified successfully"}, nil
 }
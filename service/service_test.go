@@ -2,9 +2,16 @@ package service
 
 import (
 	"context"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func Test_server_PurchaseTicket(t *testing.T) {
@@ -39,8 +46,20 @@ func Test_server_PurchaseTicket(t *testing.T) {
 				User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
 				PricePaid: 20.0,
 				Seat:      "A-0",
+				State:     train.TicketState_PAID,
+				Class:     train.SeatClass_STANDARD,
 			},
 		},
+		{
+			name:    "fail - no email",
+			args:    args{ctx: context.Background(), in: &train.PurchaseRequest{User: &train.User{FirstName: "John"}}},
+			wantErr: true,
+		},
+		{
+			name:    "fail - email not an address",
+			args:    args{ctx: context.Background(), in: &train.PurchaseRequest{User: &train.User{Email: "John Doe <john.doe@example.com>"}}},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -51,7 +70,25 @@ func Test_server_PurchaseTicket(t *testing.T) {
 				t.Errorf("server.PurchaseTicket() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			// Booking references are random; check their shape only.
+			if tt.want != nil {
+				if len(got.GetBookingReference()) != 8 {
+					t.Errorf("server.PurchaseTicket() booking reference = %q, want 8 characters", got.GetBookingReference())
+				}
+				tt.want.BookingReference = got.GetBookingReference()
+			}
+			// Transition times vary; check the states recorded only.
+			if tt.want != nil {
+				var states []train.TicketState
+				for _, change := range got.GetHistory() {
+					states = append(states, change.State)
+				}
+				if want := []train.TicketState{train.TicketState_RESERVED, train.TicketState_PAID}; !reflect.DeepEqual(states, want) {
+					t.Errorf("server.PurchaseTicket() history = %v, want %v", states, want)
+				}
+				tt.want.History = got.GetHistory()
+			}
+			if !proto.Equal(got, tt.want) {
 				t.Errorf("server.PurchaseTicket() = %v, want %v", got, tt.want)
 			}
 		})
@@ -61,7 +98,7 @@ func Test_server_PurchaseTicket(t *testing.T) {
 func Test_server_GetReceipt(t *testing.T) {
 	s := NewServer()
 
-	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+	purchased, _ := s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
 		From:      "London",
 		To:        "France",
 		User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
@@ -85,11 +122,15 @@ func Test_server_GetReceipt(t *testing.T) {
 				in:  &train.UserRequest{Email: "john.doe@example.com"},
 			},
 			want: &train.Receipt{
-				From:      "London",
-				To:        "France",
-				User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
-				PricePaid: 20.0,
-				Seat:      "A-0",
+				From:             "London",
+				To:               "France",
+				User:             &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+				PricePaid:        20.0,
+				Seat:             "A-0",
+				BookingReference: purchased.BookingReference,
+				State:            train.TicketState_PAID,
+				History:          purchased.History,
+				Class:            train.SeatClass_STANDARD,
 			},
 			wantErr: false,
 		},
@@ -110,7 +151,7 @@ func Test_server_GetReceipt(t *testing.T) {
 				t.Errorf("server.GetReceipt() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if !proto.Equal(got, tt.want) {
 				t.Errorf("server.GetReceipt() = %v, want %v", got, tt.want)
 			}
 		})
@@ -118,51 +159,126 @@ func Test_server_GetReceipt(t *testing.T) {
 }
 
 func Test_server_ViewSeats(t *testing.T) {
-	s := NewServer()
-
-	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
-		From:      "London",
-		To:        "France",
-		User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
-		PricePaid: 20.0,
-	})
+	s := NewServer(
+		WithSeatLayout([]string{"A", "B"}, 12),
+		WithSeatClasses(map[string]train.SeatClass{"B": train.SeatClass_BUSINESS}),
+	)
 
-	type args struct {
-		ctx context.Context
-		in  *train.SectionRequest
+	// Buy out of seat order so the listing has to sort.
+	for _, seat := range []string{"A-7", "B-1", "A-2", "A-10", "B-0"} {
+		email := strings.ToLower(strings.ReplaceAll(seat, "-", "")) + "@example.com"
+		_, err := s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+			User:          &train.User{FirstName: "Seat", LastName: seat, Email: email},
+			RequestedSeat: seat,
+		})
+		if err != nil {
+			t.Fatalf("PurchaseTicket(%s): %v", seat, err)
+		}
 	}
+	if _, err := s.CheckIn(context.TODO(), &train.UserRequest{Email: "a2@example.com"}); err != nil {
+		t.Fatalf("CheckIn: %v", err)
+	}
+
 	tests := []struct {
-		name    string
-		args    args
-		want    *train.SeatResponse
-		wantErr bool
+		name     string
+		in       *train.SectionRequest
+		want     []string
+		wantCode codes.Code
 	}{
 		{
-			name: "success - view seats in section A",
-			args: args{
-				ctx: context.Background(),
-				in:  &train.SectionRequest{Section: "A"},
-			},
-			want: &train.SeatResponse{
-				Users: []*train.User{
-					{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
-				},
-			},
-			wantErr: false,
+			name: "success - section A in seat order",
+			in:   &train.SectionRequest{Section: "A"},
+			want: []string{"A-2", "A-7", "A-10"},
+		},
+		{
+			name: "success - every section",
+			in:   &train.SectionRequest{},
+			want: []string{"A-2", "A-7", "A-10", "B-0", "B-1"},
+		},
+		{
+			name: "success - filtered by state",
+			in:   &train.SectionRequest{States: []train.TicketState{train.TicketState_CHECKED_IN}},
+			want: []string{"A-2"},
+		},
+		{
+			name: "success - filtered by class",
+			in:   &train.SectionRequest{Class: train.SeatClass_BUSINESS},
+			want: []string{"B-0", "B-1"},
+		},
+		{
+			name: "success - empty section",
+			in:   &train.SectionRequest{Section: "C"},
+		},
+		{
+			name:     "fail - negative page size",
+			in:       &train.SectionRequest{PageSize: -1},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "fail - invalid page token",
+			in:       &train.SectionRequest{PageToken: "not a token"},
+			wantCode: codes.InvalidArgument,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := s.ViewSeats(tt.args.ctx, tt.args.in)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("server.ViewSeats() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := s.ViewSeats(context.Background(), tt.in)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("server.ViewSeats() error = %v, want code %v", err, tt.wantCode)
+			}
+			if err != nil {
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("server.ViewSeats() = %v, want %v", got, tt.want)
+			var seats []string
+			for i, seat := range got.Seats {
+				seats = append(seats, seat.Seat)
+				receipt, _ := s.GetReceipt(context.Background(), &train.UserRequest{Email: seat.User.Email})
+				if seat.BookingReference != receipt.BookingReference || seat.Status != receipt.State || seat.Class != receipt.Class {
+					t.Errorf("seat %s = %v, want fields of receipt %v", seat.Seat, seat, receipt)
+				}
+				if !proto.Equal(got.Users[i], seat.User) {
+					t.Errorf("users[%d] = %v, want %v", i, got.Users[i], seat.User)
+				}
+			}
+			if !reflect.DeepEqual(seats, tt.want) {
+				t.Errorf("server.ViewSeats() seats = %v, want %v", seats, tt.want)
+			}
+			if len(got.Users) != len(got.Seats) {
+				t.Errorf("server.ViewSeats() has %d users for %d seats", len(got.Users), len(got.Seats))
 			}
 		})
 	}
+
+	t.Run("pagination", func(t *testing.T) {
+		in := &train.SectionRequest{PageSize: 2}
+		var pages [][]string
+		for {
+			got, err := s.ViewSeats(context.Background(), in)
+			if err != nil {
+				t.Fatalf("server.ViewSeats() error = %v", err)
+			}
+			var page []string
+			for _, seat := range got.Seats {
+				page = append(page, seat.Seat)
+			}
+			pages = append(pages, page)
+			if got.NextPageToken == "" {
+				break
+			}
+			in.PageToken = got.NextPageToken
+			if len(pages) == 1 {
+				// A seat sold behind the cursor does not shift later pages.
+				s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+					User:          &train.User{Email: "a0@example.com"},
+					RequestedSeat: "A-0",
+				})
+			}
+		}
+		want := [][]string{{"A-2", "A-7"}, {"A-10", "B-0"}, {"B-1"}}
+		if !reflect.DeepEqual(pages, want) {
+			t.Errorf("pages = %v, want %v", pages, want)
+		}
+	})
 }
 
 func Test_server_RemoveUser(t *testing.T) {
@@ -210,7 +326,7 @@ func Test_server_RemoveUser(t *testing.T) {
 }
 
 func Test_server_ModifySeat(t *testing.T) {
-	s := NewServer()
+	s := NewServer(WithBlockedSeats("A-9"))
 
 	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
 		From:      "London",
@@ -218,41 +334,91 @@ func Test_server_ModifySeat(t *testing.T) {
 		User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
 		PricePaid: 20.0,
 	})
+	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+		User:          &train.User{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"},
+		RequestedSeat: "B-0",
+	})
 
 	type args struct {
 		ctx context.Context
 		in  *train.ModifySeatRequest
 	}
 	tests := []struct {
-		name    string
-		args    args
-		want    *train.StatusResponse
-		wantErr bool
+		name     string
+		args     args
+		want     *train.StatusResponse
+		wantCode codes.Code
 	}{
 		{
 			name: "success - modify seat",
 			args: args{
 				ctx: context.Background(),
-				in:  &train.ModifySeatRequest{Email: "john.doe@example.com"},
+				in:  &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "A-5"},
 			},
-			want:    &train.StatusResponse{Message: "Seat modified successfully"},
-			wantErr: false,
+			want: &train.StatusResponse{Message: "Seat modified successfully"},
+		},
+		{
+			name: "success - keep own seat",
+			args: args{
+				ctx: context.Background(),
+				in:  &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "A-5"},
+			},
+			want: &train.StatusResponse{Message: "Seat modified successfully"},
 		},
 		{
 			name: "fail - modify seat - not found",
 			args: args{
 				ctx: context.Background(),
-				in:  &train.ModifySeatRequest{Email: "invalid@example.com"},
+				in:  &train.ModifySeatRequest{Email: "invalid@example.com", NewSeat: "A-1"},
 			},
-			want:    nil,
-			wantErr: true,
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "fail - seat held by another passenger",
+			args: args{
+				ctx: context.Background(),
+				in:  &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "B-0"},
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "fail - blocked seat",
+			args: args{
+				ctx: context.Background(),
+				in:  &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "A-9"},
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name: "fail - seat not on the train",
+			args: args{
+				ctx: context.Background(),
+				in:  &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "Z-99"},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "fail - seat number out of range",
+			args: args{
+				ctx: context.Background(),
+				in:  &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "A-10"},
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "fail - malformed seat",
+			args: args{
+				ctx: context.Background(),
+				in:  &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "B1"},
+			},
+			wantCode: codes.InvalidArgument,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got, err := s.ModifySeat(tt.args.ctx, tt.args.in)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("server.ModifySeat() error = %v, wantErr %v", err, tt.wantErr)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("server.ModifySeat() error = %v, want code %v", err, tt.wantCode)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
@@ -260,4 +426,389 @@ func Test_server_ModifySeat(t *testing.T) {
 			}
 		})
 	}
+
+	// Rejected changes leave the seats as they were.
+	seats := make(map[string]string)
+	for _, seat := range s.Receipts() {
+		seats[seat.User.Email] = seat.Seat
+	}
+	if want := map[string]string{"john.doe@example.com": "A-5", "jane.doe@example.com": "B-0"}; !reflect.DeepEqual(seats, want) {
+		t.Errorf("seats after ModifySeat = %v, want %v", seats, want)
+	}
+	if free := s.Stats().Sections["A"].Free; free != 8 {
+		t.Errorf("section A has %d free seats, want 8", free)
+	}
+}
+
+func Test_server_assignSeat(t *testing.T) {
+	s := NewServer(WithSeatLayout([]string{"A", "B", "C"}, 1))
+
+	tests := []struct {
+		name    string
+		email   string
+		want    Seat
+		wantErr bool
+	}{
+		{name: "success - first section", email: "a@example.com", want: Seat{Section: "A", Number: 0}},
+		{name: "success - second section", email: "b@example.com", want: Seat{Section: "B", Number: 0}},
+		{name: "success - third section", email: "c@example.com", want: Seat{Section: "C", Number: 0}},
+		{name: "fail - train full", email: "d@example.com", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := s.assignSeat(context.Background(), tt.email, Request{})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.assignSeat() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("server.assignSeat() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_PurchaseTicket_preferences(t *testing.T) {
+	s := NewServer(WithSeatLayout([]string{"A", "B"}, 8), WithQuietSections("B"))
+
+	// Purchases run in order against the same server.
+	tests := []struct {
+		name     string
+		prefs    *train.SeatPreferences
+		wantSeat string
+		wantMet  *train.SeatPreferences
+	}{
+		{
+			name:     "success - window in quiet coach",
+			prefs:    &train.SeatPreferences{Window: true, QuietCoach: true},
+			wantSeat: "B-0",
+			wantMet:  &train.SeatPreferences{Window: true, QuietCoach: true},
+		},
+		{
+			name:     "success - forward-facing aisle",
+			prefs:    &train.SeatPreferences{Aisle: true, ForwardFacing: true},
+			wantSeat: "A-1",
+			wantMet:  &train.SeatPreferences{Aisle: true, ForwardFacing: true},
+		},
+		{
+			name:     "success - quiet coach",
+			prefs:    &train.SeatPreferences{QuietCoach: true},
+			wantSeat: "B-1",
+			wantMet:  &train.SeatPreferences{QuietCoach: true},
+		},
+		{
+			name:     "success - no preferences fills the emptier section",
+			wantSeat: "A-0",
+		},
+		{
+			name:     "success - partly honoured",
+			prefs:    &train.SeatPreferences{Window: true, Aisle: true},
+			wantSeat: "A-2",
+			wantMet:  &train.SeatPreferences{Aisle: true},
+		},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.PurchaseTicket(context.Background(), &train.PurchaseRequest{
+				User:        &train.User{Email: strconv.Itoa(i) + "@example.com"},
+				Preferences: tt.prefs,
+			})
+			if err != nil {
+				t.Fatalf("server.PurchaseTicket() error = %v", err)
+			}
+			if got.Seat != tt.wantSeat {
+				t.Errorf("server.PurchaseTicket() seat = %s, want %s", got.Seat, tt.wantSeat)
+			}
+			if !proto.Equal(got.HonouredPreferences, tt.wantMet) {
+				t.Errorf("server.PurchaseTicket() honoured = %v, want %v", got.HonouredPreferences, tt.wantMet)
+			}
+		})
+	}
+}
+
+func Test_server_PurchaseTicket_requestedSeat(t *testing.T) {
+	s := NewServer(WithSeatLayout([]string{"A", "B"}, 4), WithBlockedSeats("B-3"))
+	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "john.doe@example.com"}, RequestedSeat: "B-2"})
+
+	tests := []struct {
+		name     string
+		seat     string
+		wantCode codes.Code
+	}{
+		{name: "success - free seat", seat: "A-3", wantCode: codes.OK},
+		{name: "fail - seat sold", seat: "B-2", wantCode: codes.AlreadyExists},
+		{name: "fail - seat blocked", seat: "B-3", wantCode: codes.AlreadyExists},
+		{name: "fail - seat not on the train", seat: "C-1", wantCode: codes.InvalidArgument},
+		{name: "fail - not a seat", seat: "window", wantCode: codes.InvalidArgument},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.PurchaseTicket(context.Background(), &train.PurchaseRequest{
+				User:          &train.User{Email: strconv.Itoa(i) + "@example.com"},
+				RequestedSeat: tt.seat,
+			})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("server.PurchaseTicket() error = %v, want %v", err, tt.wantCode)
+			}
+			if err == nil && got.Seat != tt.seat {
+				t.Errorf("server.PurchaseTicket() seat = %s, want %s", got.Seat, tt.seat)
+			}
+		})
+	}
+}
+
+func Test_server_GetSeatMap(t *testing.T) {
+	s := NewServer(WithSeatLayout([]string{"A", "B"}, 4), WithQuietSections("B"), WithBlockedSeats("B-3"))
+	s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "john.doe@example.com"}, RequestedSeat: "A-1"})
+
+	got, err := s.GetSeatMap(context.Background(), &train.SeatMapRequest{})
+	if err != nil {
+		t.Fatalf("server.GetSeatMap() error = %v", err)
+	}
+	if len(got.Seats) != 8 {
+		t.Fatalf("server.GetSeatMap() has %d seats, want 8", len(got.Seats))
+	}
+	want := map[string]*train.SeatInfo{
+		"A-0": {Seat: "A-0", Section: "A", Class: train.SeatClass_STANDARD, Status: train.SeatStatus_FREE, Attributes: &train.SeatAttributes{Window: true, ForwardFacing: true}},
+		"A-1": {Seat: "A-1", Section: "A", Class: train.SeatClass_STANDARD, Status: train.SeatStatus_SOLD, Attributes: &train.SeatAttributes{Aisle: true, ForwardFacing: true}},
+		"B-3": {Seat: "B-3", Section: "B", Class: train.SeatClass_STANDARD, Status: train.SeatStatus_BLOCKED, Attributes: &train.SeatAttributes{Window: true, ForwardFacing: true, QuietCoach: true}},
+	}
+	for _, seat := range got.Seats {
+		if w, ok := want[seat.Seat]; ok && !proto.Equal(seat, w) {
+			t.Errorf("server.GetSeatMap() seat = %v, want %v", seat, w)
+		}
+	}
+}
+
+func Test_server_GetTicketDocument(t *testing.T) {
+	s := NewServer()
+	receipt, _ := s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{
+		From:      "London",
+		To:        "France",
+		User:      &train.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		PricePaid: 20.0,
+	})
+
+	tests := []struct {
+		name            string
+		in              *train.TicketDocumentRequest
+		wantContentType string
+		wantCode        codes.Code
+	}{
+		{
+			name:            "success - pdf by default",
+			in:              &train.TicketDocumentRequest{Email: "john.doe@example.com"},
+			wantContentType: "application/pdf",
+		},
+		{
+			name:            "success - html",
+			in:              &train.TicketDocumentRequest{Email: "john.doe@example.com", Format: train.TicketDocumentRequest_HTML},
+			wantContentType: "text/html; charset=utf-8",
+		},
+		{
+			name:     "fail - unknown brand",
+			in:       &train.TicketDocumentRequest{Email: "john.doe@example.com", Brand: "missing"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "fail - no ticket",
+			in:       &train.TicketDocumentRequest{Email: "test@example.com"},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.GetTicketDocument(context.Background(), tt.in)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("server.GetTicketDocument() error = %v, want %v", err, tt.wantCode)
+			}
+			if err != nil {
+				return
+			}
+			if got.ContentType != tt.wantContentType || len(got.Content) == 0 {
+				t.Errorf("server.GetTicketDocument() = %s with %d bytes, want %s", got.ContentType, len(got.Content), tt.wantContentType)
+			}
+			if !strings.Contains(got.Filename, receipt.BookingReference) {
+				t.Errorf("server.GetTicketDocument() filename = %q, want it to contain %s", got.Filename, receipt.BookingReference)
+			}
+		})
+	}
+}
+
+func Test_server_ValidateBoardingPass(t *testing.T) {
+	s := NewServer()
+	for _, email := range []string{"john.doe@example.com", "jane.doe@example.com", "jim.doe@example.com", "joe.doe@example.com"} {
+		s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: email}})
+	}
+	pass := func(email string) string {
+		bp, err := s.GetBoardingPass(context.TODO(), &train.UserRequest{Email: email})
+		if err != nil {
+			t.Fatalf("server.GetBoardingPass() error = %v", err)
+		}
+		if !strings.HasPrefix(string(bp.QrPng), "\x89PNG") {
+			t.Errorf("server.GetBoardingPass() QR code is not a PNG")
+		}
+		return bp.Code
+	}
+	john, jane, jim, joe := pass("john.doe@example.com"), pass("jane.doe@example.com"), pass("jim.doe@example.com"), pass("joe.doe@example.com")
+	s.ModifySeat(context.TODO(), &train.ModifySeatRequest{Email: "jane.doe@example.com", NewSeat: "B-9"})
+	s.RemoveUser(context.TODO(), &train.UserRequest{Email: "jim.doe@example.com"})
+	s.CheckIn(context.TODO(), &train.UserRequest{Email: "joe.doe@example.com"})
+	s.Board(context.TODO(), &train.UserRequest{Email: "joe.doe@example.com"})
+
+	tests := []struct {
+		name string
+		code string
+		want train.ValidateBoardingPassResponse_Result
+	}{
+		{name: "success - valid pass", code: john, want: train.ValidateBoardingPassResponse_VALID},
+		{name: "fail - scanned twice", code: john, want: train.ValidateBoardingPassResponse_ALREADY_SCANNED},
+		{name: "fail - seat changed since issue", code: jane, want: train.ValidateBoardingPassResponse_SUPERSEDED},
+		{name: "fail - ticket cancelled", code: jim, want: train.ValidateBoardingPassResponse_CANCELLED},
+		{name: "fail - boarded without a scan", code: joe, want: train.ValidateBoardingPassResponse_NOT_BOARDABLE},
+		{name: "fail - tampered", code: john[:len(john)-4] + "AAAA", want: train.ValidateBoardingPassResponse_TAMPERED},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.ValidateBoardingPass(context.Background(), &train.ValidateBoardingPassRequest{Code: tt.code})
+			if err != nil {
+				t.Fatalf("server.ValidateBoardingPass() error = %v", err)
+			}
+			if got.Result != tt.want {
+				t.Errorf("server.ValidateBoardingPass() = %v, want %v", got.Result, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.GetBoardingPass(context.TODO(), &train.UserRequest{Email: "joe.doe@example.com"}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("server.GetBoardingPass() for a boarded ticket error = %v, want %v", err, codes.FailedPrecondition)
+	}
+}
+
+func Test_server_ticketLifecycle(t *testing.T) {
+	s := NewServer()
+	for _, email := range []string{"john.doe@example.com", "jane.doe@example.com"} {
+		s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: email}})
+	}
+	checkIn := func(email string) error {
+		_, err := s.CheckIn(context.TODO(), &train.UserRequest{Email: email})
+		return err
+	}
+	board := func(email string) error {
+		_, err := s.Board(context.TODO(), &train.UserRequest{Email: email})
+		return err
+	}
+	modifySeat := func(email string) error {
+		_, err := s.ModifySeat(context.TODO(), &train.ModifySeatRequest{Email: email, NewSeat: "B-9"})
+		return err
+	}
+	remove := func(email string) error {
+		_, err := s.RemoveUser(context.TODO(), &train.UserRequest{Email: email})
+		return err
+	}
+
+	// Steps run in order against the same server.
+	tests := []struct {
+		name     string
+		step     func(email string) error
+		email    string
+		wantCode codes.Code
+	}{
+		{name: "fail - board before check-in", step: board, email: "john.doe@example.com", wantCode: codes.FailedPrecondition},
+		{name: "success - check in", step: checkIn, email: "john.doe@example.com", wantCode: codes.OK},
+		{name: "fail - check in twice", step: checkIn, email: "john.doe@example.com", wantCode: codes.FailedPrecondition},
+		{name: "success - change seat after check-in", step: modifySeat, email: "john.doe@example.com", wantCode: codes.OK},
+		{name: "success - board", step: board, email: "john.doe@example.com", wantCode: codes.OK},
+		{name: "fail - change seat after boarding", step: modifySeat, email: "john.doe@example.com", wantCode: codes.FailedPrecondition},
+		{name: "fail - cancel after boarding", step: remove, email: "john.doe@example.com", wantCode: codes.FailedPrecondition},
+		{name: "fail - check in without ticket", step: checkIn, email: "test@example.com", wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.step(tt.email); status.Code(err) != tt.wantCode {
+				t.Errorf("error = %v, want %v", err, tt.wantCode)
+			}
+		})
+	}
+
+	if got := s.MarkNoShows(); got != 1 {
+		t.Errorf("server.MarkNoShows() = %d, want 1", got)
+	}
+	want := map[string]train.TicketState{
+		"john.doe@example.com": train.TicketState_BOARDED,
+		"jane.doe@example.com": train.TicketState_NO_SHOW,
+	}
+	for email, state := range want {
+		receipt, _ := s.GetReceipt(context.TODO(), &train.UserRequest{Email: email})
+		if receipt.State != state {
+			t.Errorf("%s state = %v, want %v", email, receipt.State, state)
+		}
+	}
+}
+
+func Test_server_RefundTicket(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "bookings.json"))
+	s := NewServer(WithStore(store))
+	refs, seats := make(map[string]string), make(map[string]string)
+	for i, email := range []string{"john.doe@example.com", "jane.doe@example.com", "jim.doe@example.com"} {
+		receipt, _ := s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: email}, PricePaid: float64(20 + 10*i)})
+		refs[email], seats[email] = receipt.BookingReference, receipt.Seat
+	}
+	s.RemoveUser(context.TODO(), &train.UserRequest{Email: "john.doe@example.com"})
+	s.CheckIn(context.TODO(), &train.UserRequest{Email: "jim.doe@example.com"})
+	s.Board(context.TODO(), &train.UserRequest{Email: "jim.doe@example.com"})
+	s.MarkNoShows()
+
+	// Steps run in order against the same server.
+	tests := []struct {
+		name     string
+		ref      string
+		want     float64
+		wantCode codes.Code
+	}{
+		{name: "success - cancelled ticket", ref: refs["john.doe@example.com"], want: 20},
+		{name: "fail - refunded twice", ref: refs["john.doe@example.com"], wantCode: codes.FailedPrecondition},
+		{name: "success - no-show", ref: refs["jane.doe@example.com"], want: 30},
+		{name: "fail - boarded", ref: refs["jim.doe@example.com"], wantCode: codes.FailedPrecondition},
+		{name: "fail - unknown booking reference", ref: "XXXXXX", wantCode: codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.RefundTicket(context.TODO(), &train.RefundRequest{BookingReference: tt.ref})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("server.RefundTicket() error = %v, want %v", err, tt.wantCode)
+			}
+			if err == nil && (got.Refunded != tt.want || got.Receipt.State != train.TicketState_REFUNDED) {
+				t.Errorf("server.RefundTicket() = %v, want %v refunded", got, tt.want)
+			}
+		})
+	}
+
+	if got := s.Stats().Refunds; got != 50 {
+		t.Errorf("server.Stats() refunds = %v, want 50", got)
+	}
+	// The refunded no-show no longer holds its seat.
+	if _, err := s.GetReceipt(context.TODO(), &train.UserRequest{Email: "jane.doe@example.com"}); status.Code(err) != codes.NotFound {
+		t.Errorf("server.GetReceipt() after refund error = %v, want %v", err, codes.NotFound)
+	}
+	if _, err := s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "jack.doe@example.com"}, RequestedSeat: seats["jane.doe@example.com"]}); err != nil {
+		t.Errorf("server.PurchaseTicket() of the refunded seat error = %v", err)
+	}
+	if _, err := s.PurchaseTicket(context.TODO(), &train.PurchaseRequest{User: &train.User{Email: "john.doe@example.com"}}); err != nil {
+		t.Errorf("server.PurchaseTicket() after cancelling error = %v", err)
+	}
+
+	// Cancelled tickets are saved, so they are not refunded again after a
+	// restart.
+	if err := s.Flush(context.Background()); err != nil {
+		t.Fatalf("server.Flush() error = %v", err)
+	}
+	restored := NewServer(WithStore(store))
+	if err := restored.Restore(context.Background()); err != nil {
+		t.Fatalf("server.Restore() error = %v", err)
+	}
+	_, err := restored.RefundTicket(context.TODO(), &train.RefundRequest{BookingReference: refs["john.doe@example.com"]})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("server.RefundTicket() after restore error = %v, want %v", err, codes.FailedPrecondition)
+	}
 }
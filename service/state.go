@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// transitions lists the states a ticket may move to from each state.
+var transitions = map[train.TicketState][]train.TicketState{
+	train.TicketState_TICKET_STATE_UNSPECIFIED: {train.TicketState_RESERVED},
+	train.TicketState_RESERVED:                 {train.TicketState_PAID, train.TicketState_CANCELLED},
+	train.TicketState_PAID:                     {train.TicketState_CHECKED_IN, train.TicketState_NO_SHOW, train.TicketState_CANCELLED},
+	train.TicketState_CHECKED_IN:               {train.TicketState_BOARDED, train.TicketState_NO_SHOW, train.TicketState_CANCELLED},
+	train.TicketState_NO_SHOW:                  {train.TicketState_REFUNDED},
+	train.TicketState_CANCELLED:                {train.TicketState_REFUNDED},
+}
+
+// seatChangeStates are the states in which a passenger may change seat.
+var seatChangeStates = []train.TicketState{
+	train.TicketState_RESERVED,
+	train.TicketState_PAID,
+	train.TicketState_CHECKED_IN,
+}
+
+// boardingStates are the states in which a ticket may board, and so get or
+// use a boarding pass.
+var boardingStates = []train.TicketState{
+	train.TicketState_PAID,
+	train.TicketState_CHECKED_IN,
+}
+
+// transition moves receipt to state to, recording the time in its history.
+// It returns a FailedPrecondition error if the move is not allowed.
+func transition(receipt *train.Receipt, to train.TicketState) error {
+	if !slices.Contains(transitions[receipt.State], to) {
+		return status.Errorf(codes.FailedPrecondition, "ticket is %s and cannot become %s", stateName(receipt.State), stateName(to))
+	}
+	receipt.State = to
+	receipt.History = append(receipt.History, &train.StateChange{
+		State: to,
+		Time:  timestamppb.New(time.Now()),
+	})
+	return nil
+}
+
+// stateName returns state in lower case for error messages, e.g. "checked_in".
+func stateName(state train.TicketState) string {
+	return strings.ToLower(state.String())
+}
+
+// CheckIn checks a paid ticket in for the journey.
+func (s *server) CheckIn(ctx context.Context, in *train.UserRequest) (*train.Receipt, error) {
+	return s.advance(in.Email, train.TicketState_CHECKED_IN)
+}
+
+// Board records that a checked-in passenger is on the train.
+func (s *server) Board(ctx context.Context, in *train.UserRequest) (*train.Receipt, error) {
+	return s.advance(in.Email, train.TicketState_BOARDED)
+}
+
+// advance moves the ticket held by email to state.
+func (s *server) advance(email string, state train.TicketState) (*train.Receipt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, ok := s.tickets[email]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ticket found for email: %s", email)
+	}
+	if state == train.TicketState_CHECKED_IN && receipt.OverbookedSection != "" {
+		return nil, errOverbooked
+	}
+	if err := transition(receipt, state); err != nil {
+		return nil, err
+	}
+	s.dirty = true
+	return proto.Clone(receipt).(*train.Receipt), nil
+}
+
+// release moves receipt, which has just been cancelled or refunded, out of
+// the bookings and its seat back on sale. s.mu must be held.
+func (s *server) release(receipt *train.Receipt) {
+	email := receipt.GetUser().GetEmail()
+	delete(s.tickets, email)
+	delete(s.seats, email)
+	delete(s.scanned, receipt.BookingReference)
+	s.cancelled[receipt.BookingReference] = receipt
+}
+
+// RefundTicket refunds the price paid for a cancelled or no-show ticket.
+func (s *server) RefundTicket(ctx context.Context, in *train.RefundRequest) (*train.RefundResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	receipt, ok := s.cancelled[in.BookingReference]
+	if !ok {
+		for _, r := range s.tickets {
+			if r.BookingReference == in.BookingReference {
+				receipt, ok = r, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no ticket found for booking reference: %s", in.BookingReference)
+	}
+	if err := transition(receipt, train.TicketState_REFUNDED); err != nil {
+		return nil, err
+	}
+	// A no-show is still among the bookings, holding its seat.
+	if seat, ok := s.seats[receipt.GetUser().GetEmail()]; ok && s.tickets[receipt.GetUser().GetEmail()] == receipt {
+		s.publishSeat(train.SeatEvent_SEAT_FREED, seat.Section, receipt.Seat, receipt.User)
+	}
+	s.release(receipt)
+	s.counters.refunds += receipt.PricePaid
+	s.dirty = true
+	return &train.RefundResponse{Receipt: proto.Clone(receipt).(*train.Receipt), Refunded: receipt.PricePaid}, nil
+}
+
+// purchased returns when receipt was first recorded, or the zero time for
+// tickets saved before their history was kept.
+func purchased(receipt *train.Receipt) time.Time {
+	if len(receipt.History) == 0 {
+		return time.Time{}
+	}
+	return receipt.History[0].Time.AsTime()
+}
+
+// MarkNoShows moves every ticket that has not boarded to NO_SHOW, as when
+// the train departs, and returns how many were marked.
+func (s *server) MarkNoShows() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	marked := 0
+	for _, receipt := range s.tickets {
+		if transition(receipt, train.TicketState_NO_SHOW) == nil {
+			marked++
+		}
+	}
+	if marked > 0 {
+		s.dirty = true
+	}
+	return marked
+}
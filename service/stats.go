@@ -0,0 +1,66 @@
+package service
+
+// counters tracks booking activity over the lifetime of the process.
+type counters struct {
+	ticketsIssued     int
+	removals          int
+	seatModifications int
+	revenue           float64 // sum of PricePaid over issued tickets
+	refunds           float64 // fare differences on downgrades and refunded tickets
+	compensation      float64 // paid to overbooked passengers who could not travel
+}
+
+// SectionStats describes the occupancy of one train section.
+type SectionStats struct {
+	Sold       int // seats held by current tickets
+	Free       int // seats still available for allocation
+	Overbooked int // tickets sold beyond the seats, waiting for one
+}
+
+// Stats is a point-in-time summary of the server's bookings.
+type Stats struct {
+	Sections          map[string]SectionStats
+	TicketsIssued     int
+	Removals          int
+	SeatModifications int
+	Revenue           float64
+	Refunds           float64
+	Compensation      float64
+}
+
+// Stats returns the current occupancy per section together with the
+// lifetime booking counters.
+func (s *server) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := Stats{
+		Sections:          make(map[string]SectionStats, len(s.sections)),
+		TicketsIssued:     s.counters.ticketsIssued,
+		Removals:          s.counters.removals,
+		SeatModifications: s.counters.seatModifications,
+		Revenue:           s.counters.revenue,
+		Refunds:           s.counters.refunds,
+		Compensation:      s.counters.compensation,
+	}
+
+	sold := make(map[string]int)
+	for email := range s.tickets {
+		if seat, ok := s.seats[email]; ok {
+			sold[seat.Section]++
+		}
+	}
+	blocked := make(map[string]int)
+	for seat := range s.blocked {
+		blocked[seat.Section]++
+	}
+	overbooked := s.overbooked()
+	for _, name := range s.sections {
+		stats.Sections[name] = SectionStats{
+			Overbooked: overbooked[name],
+			Sold:       sold[name],
+			Free:       s.seatsPerSection - sold[name] - blocked[name],
+		}
+	}
+	return stats
+}
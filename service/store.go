@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Store persists booking state between restarts.
+type Store interface {
+	// Load returns the last saved snapshot, or nil if nothing was saved.
+	Load() (*Snapshot, error)
+	// Save replaces the stored state with snap.
+	Save(snap *Snapshot) error
+	// Ping reports whether the store is currently reachable.
+	Ping(ctx context.Context) error
+}
+
+// Snapshot is the booking state of a server at a point in time.
+type Snapshot struct {
+	Tickets map[string]*train.Receipt
+	Seats   map[string]Seat
+	Outbox  []events.Entry // events not yet delivered to every sink
+	Scanned map[string]time.Time
+	// Cancelled holds cancelled and refunded tickets by booking reference.
+	Cancelled map[string]*train.Receipt
+}
+
+// memoryStore keeps nothing; bookings live only in the server's maps.
+type memoryStore struct{}
+
+// NewMemoryStore returns a Store that discards snapshots.
+func NewMemoryStore() Store {
+	return memoryStore{}
+}
+
+func (memoryStore) Load() (*Snapshot, error)   { return nil, nil }
+func (memoryStore) Save(*Snapshot) error       { return nil }
+func (memoryStore) Ping(context.Context) error { return nil }
+
+// fileStore keeps the booking state in a single JSON file.
+type fileStore struct {
+	path string
+}
+
+// NewFileStore returns a Store that writes snapshots to path.
+func NewFileStore(path string) Store {
+	return &fileStore{path: path}
+}
+
+// fileSnapshot is the on-disk form of a Snapshot. Receipts are encoded with
+// protojson so the file stays readable and stable across proto changes.
+type fileSnapshot struct {
+	Tickets map[string]json.RawMessage `json:"tickets"`
+	Seats   map[string]Seat            `json:"seats"`
+	Outbox  []events.Entry             `json:"outbox,omitempty"`
+	Scanned map[string]time.Time       `json:"scanned,omitempty"`
+	// Cancelled is keyed by booking reference.
+	Cancelled map[string]json.RawMessage `json:"cancelled,omitempty"`
+}
+
+// decodeReceipts decodes protojson receipts, naming each by its key in
+// errors.
+func decodeReceipts(raws map[string]json.RawMessage) (map[string]*train.Receipt, error) {
+	receipts := make(map[string]*train.Receipt, len(raws))
+	for key, raw := range raws {
+		receipt := &train.Receipt{}
+		if err := protojson.Unmarshal(raw, receipt); err != nil {
+			return nil, fmt.Errorf("decode ticket for %s: %w", key, err)
+		}
+		receipts[key] = receipt
+	}
+	return receipts, nil
+}
+
+// encodeReceipts encodes receipts with protojson, naming each by its key in
+// errors.
+func encodeReceipts(receipts map[string]*train.Receipt) (map[string]json.RawMessage, error) {
+	raws := make(map[string]json.RawMessage, len(receipts))
+	for key, receipt := range receipts {
+		raw, err := protojson.Marshal(receipt)
+		if err != nil {
+			return nil, fmt.Errorf("encode ticket for %s: %w", key, err)
+		}
+		raws[key] = raw
+	}
+	return raws, nil
+}
+
+// Load reads the snapshot file, returning nil if it does not exist yet.
+func (f *fileStore) Load() (*Snapshot, error) {
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fs fileSnapshot
+	if err := json.Unmarshal(data, &fs); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", f.path, err)
+	}
+
+	snap := &Snapshot{
+		Seats:   fs.Seats,
+		Outbox:  fs.Outbox,
+		Scanned: fs.Scanned,
+	}
+	if snap.Tickets, err = decodeReceipts(fs.Tickets); err != nil {
+		return nil, err
+	}
+	if snap.Cancelled, err = decodeReceipts(fs.Cancelled); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// Ping checks that the snapshot directory exists and is writable.
+func (f *fileStore) Ping(ctx context.Context) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), ".ping-*")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	return os.Remove(tmp.Name())
+}
+
+// Save writes snap to a temporary file and renames it over the previous
+// snapshot so a crash never leaves a partial file behind.
+func (f *fileStore) Save(snap *Snapshot) error {
+	fs := fileSnapshot{
+		Seats:   snap.Seats,
+		Outbox:  snap.Outbox,
+		Scanned: snap.Scanned,
+	}
+	var err error
+	if fs.Tickets, err = encodeReceipts(snap.Tickets); err != nil {
+		return err
+	}
+	if fs.Cancelled, err = encodeReceipts(snap.Cancelled); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(fs, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
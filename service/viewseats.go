@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPageSize bounds the seats ViewSeats returns in one page.
+const maxPageSize = 1000
+
+// seatKey orders the tickets listed by ViewSeats: by section in allocation
+// order, then seat number, with overbooked tickets last in their section.
+// The email breaks ties, so every ticket has a distinct key.
+type seatKey struct {
+	section string
+	number  int
+	email   string
+}
+
+// less reports whether a sorts before b.
+func (s *server) less(a, b seatKey) bool {
+	if ra, rb := s.sectionRank(a.section), s.sectionRank(b.section); ra != rb {
+		return ra < rb
+	}
+	if a.section != b.section {
+		return a.section < b.section
+	}
+	if a.number != b.number {
+		return a.number < b.number
+	}
+	return a.email < b.email
+}
+
+// sectionRank returns the position of section in allocation order, placing
+// sections not on the train after the others.
+func (s *server) sectionRank(section string) int {
+	if i := slices.Index(s.sections, section); i >= 0 {
+		return i
+	}
+	return len(s.sections)
+}
+
+// encode returns k as an opaque page token.
+func (k seatKey) encode() string {
+	raw := strings.Join([]string{k.section, strconv.Itoa(k.number), k.email}, "\x00")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeSeatKey parses a page token written by seatKey.encode.
+func decodeSeatKey(token string) (seatKey, bool) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return seatKey{}, false
+	}
+	parts := strings.Split(string(raw), "\x00")
+	if len(parts) != 3 {
+		return seatKey{}, false
+	}
+	number, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return seatKey{}, false
+	}
+	return seatKey{section: parts[0], number: number, email: parts[2]}, true
+}
+
+// booking is a ticket with its place in seat order.
+type booking struct {
+	key     seatKey
+	receipt *train.Receipt
+}
+
+// bookings returns every ticket with a seat or an overbooked section, in
+// seat order. s.mu must be held.
+func (s *server) bookings() []booking {
+	var list []booking
+	for email, receipt := range s.tickets {
+		key := seatKey{email: email}
+		if seat, ok := s.seats[email]; ok {
+			key.section, key.number = seat.Section, seat.Number
+		} else if receipt.OverbookedSection != "" {
+			key.section, key.number = receipt.OverbookedSection, math.MaxInt
+		} else {
+			continue
+		}
+		list = append(list, booking{key: key, receipt: receipt})
+	}
+	sort.Slice(list, func(i, j int) bool { return s.less(list[i].key, list[j].key) })
+	return list
+}
+
+// ViewSeats lists the tickets in a section, ordered by seat. The page token
+// holds the key of the last ticket returned, so pages stay consistent while
+// tickets are bought and cancelled between requests.
+func (s *server) ViewSeats(ctx context.Context, in *train.SectionRequest) (*train.SeatResponse, error) {
+	if in.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size cannot be negative")
+	}
+	var after *seatKey
+	if in.PageToken != "" {
+		key, ok := decodeSeatKey(in.PageToken)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		after = &key
+	}
+	pageSize := maxPageSize
+	if in.PageSize > 0 {
+		pageSize = min(int(in.PageSize), maxPageSize)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matches []booking
+	for _, b := range s.bookings() {
+		if in.Section != "" && b.key.section != in.Section {
+			continue
+		}
+		if len(in.States) > 0 && !slices.Contains(in.States, b.receipt.State) {
+			continue
+		}
+		if in.Class != train.SeatClass_SEAT_CLASS_UNSPECIFIED && b.receipt.Class != in.Class {
+			continue
+		}
+		if after != nil && !s.less(*after, b.key) {
+			continue
+		}
+		matches = append(matches, b)
+	}
+
+	resp := &train.SeatResponse{}
+	if len(matches) > pageSize {
+		matches = matches[:pageSize]
+		resp.NextPageToken = matches[pageSize-1].key.encode()
+	}
+	for _, m := range matches {
+		resp.Users = append(resp.Users, m.receipt.User)
+		resp.Seats = append(resp.Seats, &train.BookedSeat{
+			Seat:             m.receipt.Seat,
+			Section:          m.key.section,
+			User:             m.receipt.User,
+			BookingReference: m.receipt.BookingReference,
+			Status:           m.receipt.State,
+			Class:            m.receipt.Class,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchBuffer is how many events a subscriber may fall behind before it is
+// dropped and resynchronised with a fresh snapshot.
+const watchBuffer = 64
+
+// subscriber receives the seat events of one section.
+type subscriber struct {
+	section string
+	events  chan *train.SeatEvent
+	lagged  chan struct{} // closed when events overflowed or the broadcaster closed
+}
+
+// broadcaster fans seat events out to WatchSeats streams. Publishing never
+// blocks, so it is safe to call while holding server.mu: a subscriber whose
+// buffer is full is removed and told to resynchronise instead.
+type broadcaster struct {
+	mu     sync.Mutex // protects the following fields
+	subs   map[*subscriber]struct{}
+	closed bool
+}
+
+func newBroadcaster() *broadcaster {
+	return &broadcaster{subs: make(map[*subscriber]struct{})}
+}
+
+// subscribe registers a subscriber for section, or returns nil once the
+// broadcaster is closed.
+func (b *broadcaster) subscribe(section string) *subscriber {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	sub := &subscriber{
+		section: section,
+		events:  make(chan *train.SeatEvent, watchBuffer),
+		lagged:  make(chan struct{}),
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe removes sub if it is still registered.
+func (b *broadcaster) unsubscribe(sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.lagged)
+	}
+}
+
+// publish delivers ev to every subscriber of its section without blocking.
+func (b *broadcaster) publish(ev *train.SeatEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if sub.section != ev.Section {
+			continue
+		}
+		select {
+		case sub.events <- ev:
+		default:
+			delete(b.subs, sub)
+			close(sub.lagged)
+		}
+	}
+}
+
+// close ends every subscription and refuses new ones.
+func (b *broadcaster) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subs {
+		delete(b.subs, sub)
+		close(sub.lagged)
+	}
+}
+
+// isClosed reports whether close has been called.
+func (b *broadcaster) isClosed() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.closed
+}
+
+// WatchSeats streams a snapshot of a section followed by every change to
+// its occupancy until the client goes away or the server shuts down.
+func (s *server) WatchSeats(in *train.SectionRequest, stream train.TicketService_WatchSeatsServer) error {
+	ctx := stream.Context()
+
+	for {
+		// Take the snapshot and subscribe atomically with respect to
+		// publishers, which hold s.mu, so no event is missed or repeated.
+		s.mu.Lock()
+		snapshot := s.sectionSnapshot(in.Section)
+		sub := s.watchers.subscribe(in.Section)
+		s.mu.Unlock()
+
+		if sub == nil {
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+		if err := stream.Send(snapshot); err != nil {
+			s.watchers.unsubscribe(sub)
+			return err
+		}
+
+	forward:
+		for {
+			select {
+			case <-ctx.Done():
+				s.watchers.unsubscribe(sub)
+				return ctx.Err()
+			case <-sub.lagged:
+				// Dropped for falling behind, or shutting down; the
+				// buffered events are superseded by the next snapshot.
+				break forward
+			case ev := <-sub.events:
+				if err := stream.Send(ev); err != nil {
+					s.watchers.unsubscribe(sub)
+					return err
+				}
+			}
+		}
+
+		if s.watchers.isClosed() {
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+	}
+}
+
+// sectionSnapshot builds a SNAPSHOT event for section, sorted by seat.
+// s.mu must be held.
+func (s *server) sectionSnapshot(section string) *train.SeatEvent {
+	ev := &train.SeatEvent{Kind: train.SeatEvent_SNAPSHOT, Section: section}
+	for email, receipt := range s.tickets {
+		if seat, ok := s.seats[email]; ok && seat.Section == section {
+			ev.Seats = append(ev.Seats, &train.SeatAssignment{Seat: receipt.Seat, User: receipt.User})
+		}
+	}
+	sort.Slice(ev.Seats, func(i, j int) bool { return ev.Seats[i].Seat < ev.Seats[j].Seat })
+	return ev
+}
+
+// publishSeat announces that label in section was taken or freed by user.
+// s.mu must be held.
+func (s *server) publishSeat(kind train.SeatEvent_Kind, section, label string, user *train.User) {
+	s.watchers.publish(&train.SeatEvent{
+		Kind:    kind,
+		Section: section,
+		Seat:    &train.SeatAssignment{Seat: label, User: user},
+	})
+}
+
+// String returns the seat identifier used on receipts, e.g. "A-3".
+func (st Seat) String() string {
+	return st.Section + "-" + strconv.Itoa(st.Number)
+}
+
+// parseSeat parses a seat identifier produced by Seat.String.
+func parseSeat(label string) (Seat, bool) {
+	i := strings.LastIndex(label, "-")
+	if i <= 0 {
+		return Seat{}, false
+	}
+	n, err := strconv.Atoi(label[i+1:])
+	if err != nil || n < 0 {
+		return Seat{}, false
+	}
+	return Seat{Section: label[:i], Number: n}, true
+}
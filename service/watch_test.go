@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialServer serves s over an in-memory connection and returns a client.
+func dialServer(t *testing.T, s *server) train.TicketServiceClient {
+	t.Helper()
+	return train.NewTicketServiceClient(dial(t, s))
+}
+
+// dial serves the ticket and agent services of s over an in-memory
+// connection and returns a connection to them.
+func dial(t *testing.T, s *server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer()
+	train.RegisterTicketServiceServer(gs, s)
+	train.RegisterAgentServer(gs, s)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func Test_server_WatchSeats(t *testing.T) {
+	s := NewServer()
+	client := dialServer(t, s)
+	ctx := context.Background()
+
+	s.PurchaseTicket(ctx, &train.PurchaseRequest{User: &train.User{FirstName: "John", Email: "john.doe@example.com"}})
+
+	stream, err := client.WatchSeats(ctx, &train.SectionRequest{Section: "A"})
+	if err != nil {
+		t.Fatalf("WatchSeats() error = %v", err)
+	}
+
+	snapshot, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if snapshot.Kind != train.SeatEvent_SNAPSHOT || len(snapshot.Seats) != 1 || snapshot.Seats[0].Seat != "A-0" {
+		t.Errorf("first event = %v, want a snapshot holding A-0", snapshot)
+	}
+
+	// Changes in section A are streamed; the purchase landing in section B
+	// is not.
+	s.PurchaseTicket(ctx, &train.PurchaseRequest{User: &train.User{FirstName: "Jane", Email: "jane.doe@example.com"}})
+	s.PurchaseTicket(ctx, &train.PurchaseRequest{User: &train.User{FirstName: "Max", Email: "max@example.com"}})
+	s.ModifySeat(ctx, &train.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "B-5"})
+	s.RemoveUser(ctx, &train.UserRequest{Email: "max@example.com"})
+
+	type event struct {
+		kind train.SeatEvent_Kind
+		seat string
+	}
+	want := []event{
+		{kind: train.SeatEvent_SEAT_TAKEN, seat: "A-1"},
+		{kind: train.SeatEvent_SEAT_FREED, seat: "A-0"},
+		{kind: train.SeatEvent_SEAT_FREED, seat: "A-1"},
+	}
+	for i, w := range want {
+		ev, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("event %d: Recv() error = %v", i, err)
+		}
+		got := event{kind: ev.Kind, seat: ev.GetSeat().GetSeat()}
+		if got != w || ev.Section != "A" {
+			t.Errorf("event %d = %+v in section %s, want %+v in section A", i, got, ev.Section, w)
+		}
+	}
+
+	// Draining ends the stream.
+	s.Drain(ctx)
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Recv() after Drain error = %v, want Unavailable", err)
+	}
+}
+
+func Test_broadcaster_slowSubscriber(t *testing.T) {
+	b := newBroadcaster()
+	slow := b.subscribe("A")
+	other := b.subscribe("B")
+
+	// Publishing never blocks, even when nobody is reading.
+	for i := 0; i <= watchBuffer; i++ {
+		b.publish(&train.SeatEvent{Kind: train.SeatEvent_SEAT_TAKEN, Section: "A"})
+	}
+
+	select {
+	case <-slow.lagged:
+	default:
+		t.Error("subscriber that fell behind was not told to resynchronise")
+	}
+	select {
+	case <-other.lagged:
+		t.Error("subscriber of another section was dropped")
+	default:
+	}
+}
@@ -0,0 +1,104 @@
+// Package tracing configures OpenTelemetry for the ticket service and its
+// clients: an exporter, the global tracer provider and W3C trace context
+// propagation over gRPC metadata.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+)
+
+// Exporters accepted in Options.Exporter.
+const (
+	ExporterNone = "none" // spans are propagated but not recorded
+	ExporterOTLP = "otlp" // OTLP over gRPC to a collector
+	ExporterFile = "file" // JSON lines written to a local file
+)
+
+// Options selects where spans are exported.
+type Options struct {
+	ServiceName string
+	Exporter    string
+	Endpoint    string // OTLP collector address, e.g. localhost:4317
+	Insecure    bool   // connect to the collector without TLS
+	File        string // output path for the file exporter
+}
+
+// Setup installs the global tracer provider and propagator described by
+// opts. The returned function flushes buffered spans and releases the
+// exporter; it must be called before the process exits.
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	// Always propagate W3C trace context so traces from callers continue
+	// through this process even when nothing is exported locally.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, closeFile, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), closeFile())
+	}, nil
+}
+
+// newExporter builds the exporter for opts, or returns nil when spans are
+// not exported. closeFile releases any file opened for the exporter.
+func newExporter(ctx context.Context, opts Options) (exporter sdktrace.SpanExporter, closeFile func() error, err error) {
+	closeFile = func() error { return nil }
+
+	switch opts.Exporter {
+	case "", ExporterNone:
+		return nil, closeFile, nil
+	case ExporterOTLP:
+		clientOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+		if opts.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, clientOpts...)
+		return exporter, closeFile, err
+	case ExporterFile:
+		f, err := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: open %s: %w", opts.File, err)
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+		return exporter, f.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("tracing: unknown exporter %q", opts.Exporter)
+	}
+}
@@ -0,0 +1,78 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	train "ticketing-svc/proto"
+	"ticketing-svc/service"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "success - no exporter", opts: Options{Exporter: ExporterNone}},
+		{name: "fail - unknown exporter", opts: Options{Exporter: "jaeger"}, wantErr: true},
+		{name: "fail - file exporter without a writable file", opts: Options{Exporter: ExporterFile, File: t.TempDir()}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := Setup(context.Background(), tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Setup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				if err := shutdown(context.Background()); err != nil {
+					t.Errorf("shutdown() error = %v", err)
+				}
+			}
+		})
+	}
+}
+
+// A purchase records a span for choosing the seat and one for pricing the
+// ticket, and the file exporter writes both once tracing is shut down.
+func TestSetup_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spans.jsonl")
+	shutdown, err := Setup(context.Background(), Options{ServiceName: "ticketing-test", Exporter: ExporterFile, File: path})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	s := service.NewServer()
+	if _, err := s.PurchaseTicket(context.Background(), &train.PurchaseRequest{User: &train.User{Email: "john.doe@example.com"}, PricePaid: 20}); err != nil {
+		t.Fatalf("PurchaseTicket() error = %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var names []string
+	for dec := json.NewDecoder(f); ; {
+		var span struct{ Name string }
+		if err := dec.Decode(&span); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatalf("decode span: %v", err)
+		}
+		names = append(names, span.Name)
+	}
+	slices.Sort(names)
+	if want := []string{"assignSeat", "pricing"}; !slices.Equal(names, want) {
+		t.Errorf("exported spans = %v, want %v", names, want)
+	}
+}
@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// adminServer implements train.WebhookAdminServer on top of a Manager.
+type adminServer struct {
+	train.UnimplementedWebhookAdminServer
+	m *Manager
+}
+
+// NewAdminServer returns the WebhookAdmin service for m.
+func NewAdminServer(m *Manager) train.WebhookAdminServer {
+	return &adminServer{m: m}
+}
+
+// RegisterWebhook subscribes a URL and returns it with its secret.
+func (a *adminServer) RegisterWebhook(ctx context.Context, in *train.RegisterWebhookRequest) (*train.Webhook, error) {
+	types := make([]events.Type, len(in.EventTypes))
+	for i, t := range in.EventTypes {
+		types[i] = events.Type(t)
+	}
+
+	sub, err := a.m.Register(in.Url, in.Secret, types)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	webhook := toWebhook(sub)
+	webhook.Secret = sub.Secret
+	return webhook, nil
+}
+
+// ListWebhooks lists the subscriptions without their secrets.
+func (a *adminServer) ListWebhooks(ctx context.Context, in *train.ListWebhooksRequest) (*train.ListWebhooksResponse, error) {
+	resp := &train.ListWebhooksResponse{}
+	for _, sub := range a.m.Subscriptions() {
+		resp.Webhooks = append(resp.Webhooks, toWebhook(sub))
+	}
+	return resp, nil
+}
+
+// DeleteWebhook removes a subscription.
+func (a *adminServer) DeleteWebhook(ctx context.Context, in *train.WebhookRequest) (*train.StatusResponse, error) {
+	if err := a.m.Delete(in.Id); err != nil {
+		return nil, statusError(err)
+	}
+	return &train.StatusResponse{Message: "Webhook deleted successfully"}, nil
+}
+
+// ListDeadLetters lists the deliveries that were given up on.
+func (a *adminServer) ListDeadLetters(ctx context.Context, in *train.ListDeadLettersRequest) (*train.ListDeadLettersResponse, error) {
+	resp := &train.ListDeadLettersResponse{}
+	for _, d := range a.m.DeadLetters() {
+		resp.DeadLetters = append(resp.DeadLetters, &train.DeadLetter{
+			Id:        d.ID,
+			WebhookId: d.SubscriptionID,
+			EventId:   d.Event.ID,
+			EventType: string(d.Event.Type),
+			Attempts:  int32(d.Attempts),
+			LastError: d.LastError,
+			FailTime:  timestamppb.New(d.Failed),
+		})
+	}
+	return resp, nil
+}
+
+// ReplayWebhook queues a dead letter for delivery again.
+func (a *adminServer) ReplayWebhook(ctx context.Context, in *train.ReplayWebhookRequest) (*train.StatusResponse, error) {
+	if err := a.m.Replay(in.Id); err != nil {
+		return nil, statusError(err)
+	}
+	return &train.StatusResponse{Message: "Webhook delivery queued"}, nil
+}
+
+// toWebhook converts sub, leaving out the secret.
+func toWebhook(sub Subscription) *train.Webhook {
+	webhook := &train.Webhook{
+		Id:         sub.ID,
+		Url:        sub.URL,
+		CreateTime: timestamppb.New(sub.Created),
+	}
+	for _, t := range sub.Types {
+		webhook.EventTypes = append(webhook.EventTypes, string(t))
+	}
+	return webhook
+}
+
+// statusError maps Manager errors to gRPC status errors.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, ErrDeleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
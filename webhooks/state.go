@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// load restores the state saved in m.opts.Path, if any.
+func (m *Manager) load() error {
+	if m.opts.Path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(m.opts.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return fmt.Errorf("decode %s: %w", m.opts.Path, err)
+	}
+	for _, sub := range st.Subscriptions {
+		m.subs[sub.ID] = sub
+	}
+	m.pending = st.Pending
+	m.dead = st.DeadLetters
+	return nil
+}
+
+// save writes the state to m.opts.Path through a temporary file, so a crash
+// never leaves a partial file behind. The file holds the signing secrets and
+// is only readable by its owner. m.mu must be held.
+func (m *Manager) save() error {
+	if m.opts.Path == "" {
+		return nil
+	}
+
+	st := state{
+		Subscriptions: m.subscriptions(),
+		Pending:       m.pending,
+		DeadLetters:   m.dead,
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(m.opts.Path), filepath.Base(m.opts.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), m.opts.Path)
+}
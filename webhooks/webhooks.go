@@ -0,0 +1,403 @@
+// Package webhooks delivers booking events to partner URLs registered at
+// runtime. Deliveries are signed with HMAC-SHA256, retried with exponential
+// backoff and moved to a dead-letter list once every attempt has failed.
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"ticketing-svc/events"
+)
+
+var (
+	// ErrNotFound is returned for an unknown subscription or dead letter.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalid is returned when a subscription is rejected.
+	ErrInvalid = errors.New("invalid subscription")
+	// ErrDeleted is returned when replaying a dead letter whose
+	// subscription no longer exists.
+	ErrDeleted = errors.New("subscription deleted")
+)
+
+// Subscription is a URL registered to receive events.
+type Subscription struct {
+	ID      string        `json:"id"`
+	URL     string        `json:"url"`
+	Secret  string        `json:"secret"`
+	Types   []events.Type `json:"types,omitempty"` // empty means every type
+	Created time.Time     `json:"created"`
+}
+
+// wants reports whether events of type t are delivered to s.
+func (s Subscription) wants(t events.Type) bool {
+	return len(s.Types) == 0 || slices.Contains(s.Types, t)
+}
+
+// Delivery is one event on its way to one subscription.
+type Delivery struct {
+	ID             string       `json:"id"`
+	SubscriptionID string       `json:"subscription_id"`
+	Event          events.Event `json:"event"`
+	Attempts       int          `json:"attempts"`
+	NextAttempt    time.Time    `json:"next_attempt"`
+	LastError      string       `json:"last_error,omitempty"`
+	Failed         time.Time    `json:"failed,omitempty"` // set once dead-lettered
+}
+
+// Options configures a Manager. Zero values select the defaults.
+type Options struct {
+	// Path is the file subscriptions and queued deliveries are kept in.
+	// When empty they are held in memory only.
+	Path string
+	// MaxAttempts is how many times a delivery is tried before it is
+	// dead-lettered. Defaults to 8.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles after
+	// every failure up to MaxBackoff. Defaults to 1s and 10m.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Client sends the requests. Defaults to a client with a 10s timeout.
+	Client *http.Client
+}
+
+// Manager keeps the webhook subscriptions and delivers events to them. It
+// is an events.Sink: events it accepts are queued and sent by Run.
+type Manager struct {
+	opts Options
+	wake chan struct{} // signalled when a delivery is queued
+
+	mu      sync.Mutex // protects the following fields
+	subs    map[string]Subscription
+	pending []Delivery
+	dead    []Delivery
+}
+
+// state is the on-disk form of a Manager.
+type state struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+	Pending       []Delivery     `json:"pending"`
+	DeadLetters   []Delivery     `json:"dead_letters"`
+}
+
+// NewManager returns a Manager configured by opts, restoring its state from
+// opts.Path if the file exists.
+func NewManager(opts Options) (*Manager, error) {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 8
+	}
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 10 * time.Minute
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	m := &Manager{
+		opts: opts,
+		wake: make(chan struct{}, 1),
+		subs: make(map[string]Subscription),
+	}
+	if err := m.load(); err != nil {
+		return nil, fmt.Errorf("webhooks: %w", err)
+	}
+	return m, nil
+}
+
+// Name implements events.Sink.
+func (m *Manager) Name() string { return "webhooks" }
+
+// Deliver implements events.Sink by queueing e for every subscription that
+// wants it. The event is accepted once the queue has been saved.
+func (m *Manager) Deliver(ctx context.Context, e events.Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queued := false
+	for _, sub := range m.subs {
+		if !sub.wants(e.Type) {
+			continue
+		}
+		m.pending = append(m.pending, Delivery{
+			ID:             events.NewID(),
+			SubscriptionID: sub.ID,
+			Event:          e,
+		})
+		queued = true
+	}
+	if !queued {
+		return nil
+	}
+	if err := m.save(); err != nil {
+		return err
+	}
+	m.notify()
+	return nil
+}
+
+// Register subscribes rawURL to events of the given types, or every type
+// when types is empty. A random secret is generated when secret is empty.
+func (m *Manager) Register(rawURL, secret string, types []events.Type) (Subscription, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Subscription{}, fmt.Errorf("%w: %q is not an http(s) URL", ErrInvalid, rawURL)
+	}
+	for _, t := range types {
+		if !slices.Contains(events.Types, t) {
+			return Subscription{}, fmt.Errorf("%w: unknown event type %q", ErrInvalid, t)
+		}
+	}
+	if secret == "" {
+		secret = events.NewID() + events.NewID()
+	}
+
+	sub := Subscription{
+		ID:      events.NewID(),
+		URL:     rawURL,
+		Secret:  secret,
+		Types:   types,
+		Created: time.Now().UTC(),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.subs[sub.ID] = sub
+	if err := m.save(); err != nil {
+		delete(m.subs, sub.ID)
+		return Subscription{}, err
+	}
+	return sub, nil
+}
+
+// Delete removes the subscription with id together with its pending
+// deliveries. Its dead letters are kept but can no longer be replayed.
+func (m *Manager) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.subs[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.subs, id)
+	m.pending = slices.DeleteFunc(m.pending, func(d Delivery) bool { return d.SubscriptionID == id })
+	return m.save()
+}
+
+// Subscriptions returns every subscription, oldest first.
+func (m *Manager) Subscriptions() []Subscription {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.subscriptions()
+}
+
+// subscriptions is Subscriptions with m.mu held.
+func (m *Manager) subscriptions() []Subscription {
+	subs := make([]Subscription, 0, len(m.subs))
+	for _, sub := range m.subs {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Created.Before(subs[j].Created) })
+	return subs
+}
+
+// DeadLetters returns the deliveries that failed on every attempt, oldest
+// first.
+func (m *Manager) DeadLetters() []Delivery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.dead)
+}
+
+// Replay moves the dead letter with id back to the queue with a fresh set of
+// attempts.
+func (m *Manager) Replay(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	i := slices.IndexFunc(m.dead, func(d Delivery) bool { return d.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	d := m.dead[i]
+	if _, ok := m.subs[d.SubscriptionID]; !ok {
+		return ErrDeleted
+	}
+
+	m.dead = slices.Delete(m.dead, i, i+1)
+	d.Attempts, d.NextAttempt, d.LastError, d.Failed = 0, time.Time{}, "", time.Time{}
+	m.pending = append(m.pending, d)
+	if err := m.save(); err != nil {
+		return err
+	}
+	m.notify()
+	return nil
+}
+
+// Run sends queued deliveries as they become due until ctx is done.
+func (m *Manager) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.wake:
+		case <-timer.C:
+		}
+
+		next := m.sendDue(ctx)
+		timer.Stop()
+		if !next.IsZero() {
+			timer.Reset(time.Until(next))
+		}
+	}
+}
+
+// sendDue attempts every delivery whose time has come and returns when the
+// next one is due, or the zero time if the queue is empty. Each subscription
+// is sent to in parallel, so a slow partner does not hold up the others;
+// deliveries to one subscription are sent in order.
+func (m *Manager) sendDue(ctx context.Context) time.Time {
+	now := time.Now()
+
+	m.mu.Lock()
+	var due []Delivery
+	for _, d := range m.pending {
+		if !d.NextAttempt.After(now) {
+			due = append(due, d)
+		}
+	}
+	subs := make(map[string]Subscription, len(m.subs))
+	for id, sub := range m.subs {
+		subs[id] = sub
+	}
+	m.mu.Unlock()
+
+	bySub := make(map[string][]Delivery)
+	for _, d := range due {
+		bySub[d.SubscriptionID] = append(bySub[d.SubscriptionID], d)
+	}
+	var (
+		wg          sync.WaitGroup
+		resultsMu   sync.Mutex // protects retry and dead
+		retry, dead []Delivery
+	)
+	for id, deliveries := range bySub {
+		sub, ok := subs[id]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(sub Subscription, deliveries []Delivery) {
+			defer wg.Done()
+			for _, d := range deliveries {
+				d, ok := m.attempt(ctx, sub, d)
+				if ok {
+					continue
+				}
+				resultsMu.Lock()
+				if d.Failed.IsZero() {
+					retry = append(retry, d)
+				} else {
+					dead = append(dead, d)
+				}
+				resultsMu.Unlock()
+			}
+		}(sub, deliveries)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Deliveries queued while sending were appended after the ones taken,
+	// and deleted subscriptions may have dropped some of them.
+	sent := make(map[string]bool, len(due))
+	for _, d := range due {
+		sent[d.ID] = true
+	}
+	pending := make([]Delivery, 0, len(m.pending))
+	for _, d := range m.pending {
+		if !sent[d.ID] {
+			pending = append(pending, d)
+		}
+	}
+	for _, d := range retry {
+		if _, ok := m.subs[d.SubscriptionID]; ok {
+			pending = append(pending, d)
+		}
+	}
+	m.pending = pending
+	m.dead = append(m.dead, dead...)
+	if len(due) > 0 {
+		if err := m.save(); err != nil {
+			log.Printf("webhooks: failed to save state: %v", err)
+		}
+	}
+
+	var next time.Time
+	for _, d := range m.pending {
+		if next.IsZero() || d.NextAttempt.Before(next) {
+			next = d.NextAttempt
+		}
+	}
+	return next
+}
+
+// backoff returns the delay before the retry following the given number of
+// failed attempts.
+func (m *Manager) backoff(attempts int) time.Duration {
+	d := m.opts.InitialBackoff
+	for i := 1; i < attempts && d < m.opts.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, m.opts.MaxBackoff)
+}
+
+// attempt sends d to sub, signed with its secret, and reports whether it was
+// acknowledged. Otherwise it returns d updated for a retry, or with Failed
+// set once it has run out of attempts.
+func (m *Manager) attempt(ctx context.Context, sub Subscription, d Delivery) (Delivery, bool) {
+	err := events.Post(ctx, m.opts.Client, sub.URL, sub.Secret, d.Event)
+	if err == nil {
+		return d, true
+	}
+	if ctx.Err() != nil {
+		// Shutting down: keep the delivery for the next start without
+		// counting the interrupted attempt.
+		return d, false
+	}
+
+	d.Attempts++
+	d.LastError = err.Error()
+	if d.Attempts >= m.opts.MaxAttempts {
+		d.Failed = time.Now().UTC()
+		log.Printf("webhook %s: giving up on event %s after %d attempts: %v", sub.URL, d.Event.ID, d.Attempts, err)
+		return d, false
+	}
+	d.NextAttempt = time.Now().Add(m.backoff(d.Attempts))
+	return d, false
+}
+
+// notify wakes up Run.
+func (m *Manager) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,179 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"ticketing-svc/events"
+	train "ticketing-svc/proto"
+)
+
+// receiver is a webhook endpoint that verifies signatures and fails the
+// first failures requests.
+type receiver struct {
+	mu       sync.Mutex
+	secret   string
+	failures int
+	calls    int
+	got      []events.Event
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	if !events.Verify(r.secret, req.Header.Get(events.SignatureHeader), req.Header.Get(events.TimestampHeader), body, time.Minute) {
+		http.Error(w, "bad signature", http.StatusUnauthorized)
+		return
+	}
+	if r.calls <= r.failures {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+		return
+	}
+	var e events.Event
+	json.Unmarshal(body, &e)
+	r.got = append(r.got, e)
+}
+
+func (r *receiver) received() []events.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]events.Event(nil), r.got...)
+}
+
+func TestManager(t *testing.T) {
+	purchase := events.FromReceipt(events.TicketPurchased, &train.Receipt{User: &train.User{Email: "john.doe@example.com"}})
+	cancel := events.FromReceipt(events.TicketCancelled, &train.Receipt{User: &train.User{Email: "john.doe@example.com"}})
+
+	tests := []struct {
+		name     string
+		types    []events.Type
+		secret   string
+		failures int
+		wantGot  int
+		wantDead int
+	}{
+		{name: "success - signed delivery", secret: "s3cret", wantGot: 2},
+		{name: "success - filtered by type", types: []events.Type{events.TicketCancelled}, wantGot: 1},
+		{name: "success - delivered after retries", failures: 2, wantGot: 2},
+		{name: "fail - dead-lettered after max attempts", failures: 100, wantDead: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recv := &receiver{failures: tt.failures}
+			srv := httptest.NewServer(recv)
+			defer srv.Close()
+
+			m, err := NewManager(Options{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond})
+			if err != nil {
+				t.Fatalf("NewManager() error = %v", err)
+			}
+			sub, err := m.Register(srv.URL, tt.secret, tt.types)
+			if err != nil {
+				t.Fatalf("Manager.Register() error = %v", err)
+			}
+			recv.secret = sub.Secret
+
+			ctx, stop := context.WithCancel(context.Background())
+			defer stop()
+			go m.Run(ctx)
+			m.Deliver(ctx, purchase)
+			m.Deliver(ctx, cancel)
+
+			deadline := time.Now().Add(5 * time.Second)
+			for len(recv.received()) < tt.wantGot || len(m.DeadLetters()) < tt.wantDead {
+				if time.Now().After(deadline) {
+					t.Fatalf("received %d events and %d dead letters, want %d and %d",
+						len(recv.received()), len(m.DeadLetters()), tt.wantGot, tt.wantDead)
+				}
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(20 * time.Millisecond) // nothing more arrives
+			if got := len(recv.received()); got != tt.wantGot {
+				t.Errorf("received %d events, want %d", got, tt.wantGot)
+			}
+			if got := len(m.DeadLetters()); got != tt.wantDead {
+				t.Errorf("dead letters = %d, want %d", got, tt.wantDead)
+			}
+		})
+	}
+}
+
+func TestManager_Replay(t *testing.T) {
+	recv := &receiver{failures: 1}
+	srv := httptest.NewServer(recv)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	m, _ := NewManager(Options{Path: path, MaxAttempts: 1})
+	sub, _ := m.Register(srv.URL, "s3cret", nil)
+	recv.secret = sub.Secret
+
+	e := events.FromReceipt(events.SeatModified, &train.Receipt{User: &train.User{Email: "john.doe@example.com"}})
+	m.Deliver(context.Background(), e)
+	m.sendDue(context.Background())
+
+	// The dead letter, like the subscription, survives a restart.
+	restored, err := NewManager(Options{Path: path, MaxAttempts: 1})
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	dead := restored.DeadLetters()
+	if len(dead) != 1 || dead[0].Event.ID != e.ID || dead[0].LastError == "" {
+		t.Fatalf("DeadLetters() = %+v, want the failed %s delivery", dead, e.ID)
+	}
+
+	if err := restored.Replay(dead[0].ID); err != nil {
+		t.Fatalf("Manager.Replay() error = %v", err)
+	}
+	restored.sendDue(context.Background())
+	if got := recv.received(); len(got) != 1 || got[0].ID != e.ID {
+		t.Errorf("received %+v after replay, want %s", got, e.ID)
+	}
+	if got := restored.DeadLetters(); len(got) != 0 {
+		t.Errorf("DeadLetters() after replay = %+v, want none", got)
+	}
+	if err := restored.Replay(dead[0].ID); err != ErrNotFound {
+		t.Errorf("second Manager.Replay() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestManager_SlowPartner(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+	fast := &receiver{}
+	srv := httptest.NewServer(fast)
+	defer srv.Close()
+
+	m, _ := NewManager(Options{})
+	m.Register(slow.URL, "", nil)
+	sub, _ := m.Register(srv.URL, "", nil)
+	fast.secret = sub.Secret
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go m.Run(ctx)
+	m.Deliver(ctx, events.FromReceipt(events.TicketPurchased, &train.Receipt{User: &train.User{Email: "john.doe@example.com"}}))
+
+	// The fast partner gets the event while the slow one is still waiting.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(fast.received()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("fast partner received nothing while the slow one was waiting")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}